@@ -0,0 +1,111 @@
+package configx
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ByteSize 字节大小类型
+// 配置文件中可以使用 "512MiB"、"1.5GB"、"64k" 或纯数字（字节）表示
+// 二进制单位（KiB/MiB/GiB/TiB 及简写 K/M/G/T）按 1024 进位，十进制单位（KB/MB/GB/TB）按 1000 进位
+type ByteSize uint64
+
+// 常用字节大小
+const (
+	Byte ByteSize = 1
+	KiB           = 1024 * Byte
+	MiB           = 1024 * KiB
+	GiB           = 1024 * MiB
+	TiB           = 1024 * GiB
+	KB            = 1000 * Byte
+	MB            = 1000 * KB
+	GB            = 1000 * MB
+	TB            = 1000 * GB
+)
+
+// byteUnits 字节单位（键为小写）
+var byteUnits = map[string]ByteSize{
+	"":    Byte,
+	"b":   Byte,
+	"k":   KiB,
+	"ki":  KiB,
+	"kib": KiB,
+	"m":   MiB,
+	"mi":  MiB,
+	"mib": MiB,
+	"g":   GiB,
+	"gi":  GiB,
+	"gib": GiB,
+	"t":   TiB,
+	"ti":  TiB,
+	"tib": TiB,
+	"kb":  KB,
+	"mb":  MB,
+	"gb":  GB,
+	"tb":  TB,
+}
+
+// ParseByteSize 解析字节大小字符串
+// 参数：
+//
+//	s: 字节大小，如 "512MiB"、"1.5GB"、"1024"
+//
+// 返回值：
+//
+//	ByteSize: 解析结果
+//	error: 格式或单位无效时返回错误
+func ParseByteSize(s string) (ByteSize, error) {
+	str := strings.TrimSpace(s)
+	i := strings.IndexFunc(str, func(r rune) bool {
+		return !unicode.IsDigit(r) && r != '.'
+	})
+	if i < 0 {
+		i = len(str)
+	}
+	num, unit := str[:i], strings.ToLower(strings.TrimSpace(str[i:]))
+
+	multiplier, ok := byteUnits[unit]
+	if num == "" || !ok {
+		return 0, fmt.Errorf("无效的字节大小: %q", s)
+	}
+	value, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("无效的字节大小: %q", s)
+	}
+	size := value * float64(multiplier)
+	if size > math.MaxUint64 {
+		return 0, fmt.Errorf("字节大小溢出: %q", s)
+	}
+	return ByteSize(size), nil
+}
+
+// String 返回可读的字节大小，使用能整除的最大二进制单位，如 "512MiB"
+func (b ByteSize) String() string {
+	for _, u := range []struct {
+		size ByteSize
+		name string
+	}{{TiB, "TiB"}, {GiB, "GiB"}, {MiB, "MiB"}, {KiB, "KiB"}} {
+		if b >= u.size && b%u.size == 0 {
+			return fmt.Sprintf("%d%s", b/u.size, u.name)
+		}
+	}
+	return fmt.Sprintf("%dB", uint64(b))
+}
+
+// MarshalText 实现 encoding.TextMarshaler，使 JSON/YAML 输出可读形式
+func (b ByteSize) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	size, err := ParseByteSize(string(text))
+	if err != nil {
+		return err
+	}
+	*b = size
+	return nil
+}
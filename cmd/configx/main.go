@@ -0,0 +1,101 @@
+// configx 命令行工具
+//
+// 用法：
+//
+//	configx keygen                                  生成 base64 编码的随机密钥
+//	configx encrypt -key-file key.txt "p@ssw0rd"    加密单个配置值
+//	configx decrypt -key-env CONFIGX_KEY "ENC[...]" 解密单个配置值
+//
+// 值参数为 "-" 或省略时从标准输入读取
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kawaiirei0/configx/v2"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "configx: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run 执行子命令
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	switch args[0] {
+	case "keygen":
+		key, err := configx.GenerateKey()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, key)
+		return nil
+	case "encrypt", "decrypt":
+		return runCrypt(args[0], args[1:], stdin, stdout)
+	default:
+		return fmt.Errorf("未知的子命令 %q\n%s", args[0], usage)
+	}
+}
+
+// runCrypt 执行 encrypt / decrypt 子命令
+func runCrypt(cmd string, args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	keyFile := fs.String("key-file", "", "密钥文件路径（base64 或 hex 编码）")
+	keyEnv := fs.String("key-env", "", "保存密钥的环境变量名")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var provider configx.KeyProvider
+	switch {
+	case *keyFile != "" && *keyEnv != "":
+		return errors.New("-key-file 与 -key-env 只能指定一个")
+	case *keyFile != "":
+		provider = configx.FileKeyProvider(*keyFile)
+	case *keyEnv != "":
+		provider = configx.EnvKeyProvider(*keyEnv)
+	default:
+		return errors.New("必须通过 -key-file 或 -key-env 指定密钥")
+	}
+	key, err := provider.Key()
+	if err != nil {
+		return err
+	}
+
+	value := fs.Arg(0)
+	if value == "" || value == "-" {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return err
+		}
+		value = strings.TrimRight(string(data), "\r\n")
+	}
+
+	var out string
+	if cmd == "encrypt" {
+		out, err = configx.EncryptValue(value, key)
+	} else {
+		out, err = configx.DecryptValue(value, key)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, out)
+	return nil
+}
+
+const usage = `用法:
+  configx keygen
+  configx encrypt (-key-file FILE | -key-env NAME) [VALUE]
+  configx decrypt (-key-file FILE | -key-env NAME) [VALUE]`
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kawaiirei0/configx/v2"
+)
+
+// TestKeygenEncryptDecrypt 测试生成密钥后加密、解密单个配置值
+func TestKeygenEncryptDecrypt(t *testing.T) {
+	var key bytes.Buffer
+	if err := run([]string{"keygen"}, nil, &key); err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "config.key")
+	if err := os.WriteFile(keyFile, key.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var sealed bytes.Buffer
+	if err := run([]string{"encrypt", "-key-file", keyFile, "p@ssw0rd"}, nil, &sealed); err != nil {
+		t.Fatal(err)
+	}
+	if !configx.IsEncrypted(strings.TrimSpace(sealed.String())) {
+		t.Fatalf("加密结果应为 ENC[...]: %q", sealed.String())
+	}
+
+	// 从环境变量读取密钥，从标准输入读取密文
+	t.Setenv("CONFIGX_TEST_KEY", strings.TrimSpace(key.String()))
+	var plain bytes.Buffer
+	if err := run([]string{"decrypt", "-key-env", "CONFIGX_TEST_KEY"}, strings.NewReader(sealed.String()), &plain); err != nil {
+		t.Fatal(err)
+	}
+	if plain.String() != "p@ssw0rd\n" {
+		t.Errorf("解密结果错误: %q", plain.String())
+	}
+}
+
+// TestRunErrors 测试参数错误
+func TestRunErrors(t *testing.T) {
+	cases := map[string][]string{
+		"缺少子命令":   nil,
+		"未知的子命令":  {"rotate"},
+		"缺少密钥":    {"encrypt", "value"},
+		"重复指定密钥":  {"encrypt", "-key-file", "a", "-key-env", "B", "value"},
+		"密钥文件不存在": {"decrypt", "-key-file", filepath.Join(t.TempDir(), "missing"), "ENC[x]"},
+	}
+	for name, args := range cases {
+		if err := run(args, strings.NewReader(""), &bytes.Buffer{}); err == nil {
+			t.Errorf("%s: 应返回错误", name)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package configx
 
 import (
+	"context"
 	"sync"
 	"testing"
 	"time"
@@ -193,3 +194,36 @@ func TestConcurrentConfigReload(t *testing.T) {
 
 	wg.Wait()
 }
+
+// TestConcurrentUpdateAndReload 测试 UpdateField 与热重载并发时修改不会丢失
+func TestConcurrentUpdateAndReload(t *testing.T) {
+	type TestConfig struct {
+		Count int `mapstructure:"count"`
+	}
+
+	manager := newTestManager[TestConfig](t, "count: 0\n", nil)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	const updates = 20
+	var wg sync.WaitGroup
+	for i := 0; i < updates; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if err := manager.UpdateField(func(c *TestConfig) { c.Count++ }); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = manager.Reload(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	if cfg, _ := manager.GetConfig(); cfg.Count != updates {
+		t.Errorf("并发重载导致修改丢失: count = %d，期望 %d", cfg.Count, updates)
+	}
+}
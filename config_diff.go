@@ -0,0 +1,94 @@
+package configx
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// changedKeys 比较两个配置对象，返回值发生变化的配置字段路径（按字母排序）
+// 路径使用 mapstructure 标签名（小写），与配置文件中的键一致，如 "database.host"
+// 字符串键的映射逐项比较，其余切片、映射等整体比较
+func changedKeys(oldCfg, newCfg any) []string {
+	var keys []string
+	diffValues(reflect.ValueOf(oldCfg), reflect.ValueOf(newCfg), "", &keys)
+	sort.Strings(keys)
+	return keys
+}
+
+// diffValues 递归比较两个值并收集变化的字段路径
+func diffValues(oldVal, newVal reflect.Value, path string, out *[]string) {
+	if !oldVal.IsValid() || !newVal.IsValid() {
+		if oldVal.IsValid() != newVal.IsValid() {
+			*out = append(*out, path)
+		}
+		return
+	}
+	if oldVal.Type() != newVal.Type() {
+		*out = append(*out, path)
+		return
+	}
+
+	switch {
+	case oldVal.Kind() == reflect.Pointer:
+		if oldVal.IsNil() || newVal.IsNil() {
+			if oldVal.IsNil() != newVal.IsNil() {
+				*out = append(*out, path)
+			}
+			return
+		}
+		diffValues(oldVal.Elem(), newVal.Elem(), path, out)
+	case oldVal.Kind() == reflect.Interface:
+		diffValues(oldVal.Elem(), newVal.Elem(), path, out)
+	case oldVal.Kind() == reflect.Struct && !isLeafValue(oldVal):
+		diffStruct(oldVal, newVal, path, out)
+	case oldVal.Kind() == reflect.Map && oldVal.Type().Key().Kind() == reflect.String:
+		seen := make(map[string]bool)
+		for _, key := range append(oldVal.MapKeys(), newVal.MapKeys()...) {
+			name := key.String()
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			diffValues(oldVal.MapIndex(key), newVal.MapIndex(key), joinKeyPath(path, strings.ToLower(name)), out)
+		}
+	default:
+		if !reflect.DeepEqual(oldVal.Interface(), newVal.Interface()) {
+			*out = append(*out, path)
+		}
+	}
+}
+
+// diffStruct 按 mapstructure 标签逐字段比较结构体
+func diffStruct(oldVal, newVal reflect.Value, prefix string, out *[]string) {
+	t := oldVal.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+		if name == "-" {
+			continue
+		}
+		path := prefix
+		squash := strings.Contains(opts, "squash") || (f.Anonymous && name == "")
+		if !squash && !strings.Contains(opts, "remain") {
+			if name == "" {
+				name = f.Name
+			}
+			path = joinKeyPath(prefix, strings.ToLower(name))
+		}
+		diffValues(oldVal.Field(i), newVal.Field(i), path, out)
+	}
+}
+
+// formatKeys 将字段路径列表格式化为简短的描述
+func formatKeys(keys []string) string {
+	const limit = 10
+	if len(keys) <= limit {
+		return strings.Join(keys, ", ")
+	}
+	return fmt.Sprintf("%s 等 %d 个字段", strings.Join(keys[:limit], ", "), len(keys))
+}
@@ -1,15 +1,27 @@
 package configx
 
-import "github.com/fsnotify/fsnotify"
+import (
+	"context"
+
+	"github.com/fsnotify/fsnotify"
+)
 
 // HandlerFunc 配置变更回调函数类型
 type HandlerFunc func(ctx *Context)
 
 // Context 配置变更回调上下文
 // 提供配置变更事件信息和访问管理器的能力
+// 内嵌的 context.Context 在回调超时（WithTimeout）或注销时被取消
 type Context struct {
+	context.Context
 	// FSEvent 文件系统变更事件
 	FSEvent fsnotify.Event
+	// Trigger 触发本次重载的来源
+	Trigger ReloadTrigger
+	// Sequence 配置变更事件序号，同一回调收到的序号严格递增
+	Sequence uint64
+	// ChangedKeys 值发生变化的配置字段路径
+	ChangedKeys []string
 	// manager 存储管理器实例（类型为 interface{} 以支持泛型）
 	// 使用 GetManager[T]() 方法获取类型安全的管理器实例
 	manager interface{}
@@ -0,0 +1,206 @@
+package configx
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 加密值格式：ENC[AES256_GCM,<base64(nonce || ciphertext)>]
+const (
+	encryptedPrefix = "ENC[AES256_GCM,"
+	encryptedSuffix = "]"
+	// KeySize AES-256 密钥长度（字节）
+	KeySize = 32
+)
+
+// KeyProvider 加密密钥提供者
+// 用于解密配置文件中的 ENC[...] 值，以及 UpdateField 写回时重新加密
+type KeyProvider interface {
+	// Key 返回 32 字节的 AES-256 密钥
+	Key() ([]byte, error)
+}
+
+// KeyProviderFunc 函数形式的 KeyProvider
+type KeyProviderFunc func() ([]byte, error)
+
+// Key 实现 KeyProvider 接口
+func (f KeyProviderFunc) Key() ([]byte, error) {
+	return f()
+}
+
+// FileKeyProvider 从文件读取密钥
+// 文件内容为 base64 或 hex 编码的 32 字节密钥（首尾空白会被忽略）
+func FileKeyProvider(path string) KeyProvider {
+	return KeyProviderFunc(func() ([]byte, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("读取密钥文件失败: %w", err)
+		}
+		return ParseKey(string(data))
+	})
+}
+
+// EnvKeyProvider 从环境变量读取密钥
+// 环境变量值为 base64 或 hex 编码的 32 字节密钥
+func EnvKeyProvider(name string) KeyProvider {
+	return KeyProviderFunc(func() ([]byte, error) {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return nil, fmt.Errorf("环境变量 %s 未设置", name)
+		}
+		return ParseKey(value)
+	})
+}
+
+// GenerateKey 生成随机密钥，返回 base64 编码形式
+func GenerateKey() (string, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// ParseKey 解析 base64 或 hex 编码的密钥
+func ParseKey(encoded string) ([]byte, error) {
+	encoded = strings.TrimSpace(encoded)
+	if key, err := base64.StdEncoding.DecodeString(encoded); err == nil && len(key) == KeySize {
+		return key, nil
+	}
+	if key, err := hex.DecodeString(encoded); err == nil && len(key) == KeySize {
+		return key, nil
+	}
+	return nil, fmt.Errorf("无效的密钥：需要 base64 或 hex 编码的 %d 字节密钥", KeySize)
+}
+
+// IsEncrypted 判断字符串是否为加密值
+func IsEncrypted(s string) bool {
+	return strings.HasPrefix(s, encryptedPrefix) && strings.HasSuffix(s, encryptedSuffix)
+}
+
+// EncryptValue 使用 AES-256-GCM 加密单个配置值
+// 返回值：
+//
+//	string: ENC[AES256_GCM,...] 形式的密文
+//	error: 密钥无效时返回错误
+func EncryptValue(plaintext string, key []byte) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed) + encryptedSuffix, nil
+}
+
+// DecryptValue 解密 ENC[AES256_GCM,...] 形式的配置值
+// 返回值：
+//
+//	string: 明文
+//	error: 格式无效、密钥错误或数据被篡改时返回 ErrDecryptFailed
+func DecryptValue(value string, key []byte) (string, error) {
+	if !IsEncrypted(value) {
+		return "", fmt.Errorf("%w: 不是加密值", ErrDecryptFailed)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	payload := strings.TrimSuffix(strings.TrimPrefix(value, encryptedPrefix), encryptedSuffix)
+	sealed, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil || len(sealed) < gcm.NonceSize() {
+		return "", fmt.Errorf("%w: 密文格式无效", ErrDecryptFailed)
+	}
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrDecryptFailed, err)
+	}
+	return string(plaintext), nil
+}
+
+// newGCM 创建 AES-256-GCM 实例
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("无效的密钥长度 %d，需要 %d 字节", len(key), KeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// decryptSettings 解密配置映射中的全部加密值（直接修改 settings）
+// 参数：
+//
+//	settings: 配置映射
+//	provider: 密钥提供者，为 nil 且存在加密值时返回错误
+//
+// 返回值：
+//
+//	map[string]string: 加密字段路径到原始密文的映射（用于 UpdateField 重新加密）
+//	error: 解密失败时返回错误，包含全部失败字段的路径
+func decryptSettings(settings map[string]any, provider KeyProvider) (map[string]string, error) {
+	encrypted := make(map[string]string)
+	collectEncrypted(settings, "", encrypted)
+	if len(encrypted) == 0 {
+		return encrypted, nil
+	}
+	if provider == nil {
+		return nil, fmt.Errorf("%w: 配置中包含加密值，但未设置 Option.KeyProvider", ErrDecryptFailed)
+	}
+	key, err := provider.Key()
+	if err != nil {
+		return nil, fmt.Errorf("%w: 获取密钥失败: %w", ErrDecryptFailed, err)
+	}
+
+	paths := make([]string, 0, len(encrypted))
+	for path := range encrypted {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var errs []error
+	for _, path := range paths {
+		plaintext, err := DecryptValue(encrypted[path], key)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			continue
+		}
+		storeSetting(settings, path, plaintext)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return encrypted, nil
+}
+
+// collectEncrypted 递归收集配置映射中的加密值
+func collectEncrypted(value any, path string, out map[string]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, item := range v {
+			collectEncrypted(item, joinKeyPath(path, k), out)
+		}
+	case []any:
+		for i, item := range v {
+			collectEncrypted(item, path+"["+strconv.Itoa(i)+"]", out)
+		}
+	case string:
+		if IsEncrypted(v) {
+			out[path] = v
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package configx
+
+import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"regexp"
+	"testing"
+)
+
+type encryptTestConfig struct {
+	Database struct {
+		User     string `mapstructure:"user"`
+		Password Secret `mapstructure:"password"`
+	} `mapstructure:"database"`
+}
+
+// testKeyProvider 生成测试用密钥
+func testKeyProvider(t *testing.T) (KeyProvider, []byte) {
+	t.Helper()
+	encoded, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, _ := base64.StdEncoding.DecodeString(encoded)
+	t.Setenv("CONFIGX_TEST_KEY", encoded)
+	return EnvKeyProvider("CONFIGX_TEST_KEY"), key
+}
+
+// TestEncryptedValues 测试加载时透明解密
+func TestEncryptedValues(t *testing.T) {
+	provider, key := testKeyProvider(t)
+	sealed, err := EncryptValue("p@ss", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := NewOption()
+	opts.KeyProvider = provider
+	manager := newTestManager[encryptTestConfig](t, "database:\n  user: app\n  password: "+sealed+"\n", opts)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	if manager.config.Database.Password.Reveal() != "p@ss" {
+		t.Errorf("解密结果错误: %q", manager.config.Database.Password.Reveal())
+	}
+}
+
+// TestEncryptedValuesWrongKey 测试密钥错误或缺失时拒绝加载
+func TestEncryptedValuesWrongKey(t *testing.T) {
+	_, key := testKeyProvider(t)
+	sealed, _ := EncryptValue("p@ss", key)
+	content := "database:\n  password: " + sealed + "\n"
+
+	manager := newTestManager[encryptTestConfig](t, content, nil)
+	if err := manager.LoadConfig(); !errors.Is(err, ErrDecryptFailed) {
+		t.Errorf("未设置 KeyProvider 时期望 ErrDecryptFailed，实际: %v", err)
+	}
+
+	opts := NewOption()
+	other, _ := GenerateKey()
+	opts.KeyProvider = KeyProviderFunc(func() ([]byte, error) { return ParseKey(other) })
+	manager = newTestManager[encryptTestConfig](t, content, opts)
+	if err := manager.LoadConfig(); !errors.Is(err, ErrDecryptFailed) {
+		t.Errorf("密钥错误时期望 ErrDecryptFailed，实际: %v", err)
+	}
+}
+
+// TestUpdateFieldReencrypts 测试 UpdateField 写回时重新加密
+func TestUpdateFieldReencrypts(t *testing.T) {
+	provider, key := testKeyProvider(t)
+	sealed, _ := EncryptValue("old", key)
+
+	opts := NewOption()
+	opts.KeyProvider = provider
+	manager := newTestManager[encryptTestConfig](t, "database:\n  password: "+sealed+"\n", opts)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	if err := manager.UpdateField(func(c *encryptTestConfig) { c.Database.Password = "new" }); err != nil {
+		t.Fatalf("更新配置失败: %v", err)
+	}
+
+	data, _ := os.ReadFile(manager.vp.ConfigFileUsed())
+	match := regexp.MustCompile(`password: "(ENC\[[^\]]+\])"`).FindSubmatch(data)
+	if match == nil {
+		t.Fatalf("写回内容未加密:\n%s", data)
+	}
+	if plaintext, err := DecryptValue(string(match[1]), key); err != nil || plaintext != "new" {
+		t.Errorf("重新加密的值错误: %q, %v", plaintext, err)
+	}
+
+	// 再次修改时应按新密文匹配并写回
+	if err := manager.UpdateField(func(c *encryptTestConfig) { c.Database.Password = "newer" }); err != nil {
+		t.Fatalf("再次更新配置失败: %v", err)
+	}
+	data, _ = os.ReadFile(manager.vp.ConfigFileUsed())
+	match = regexp.MustCompile(`password: "(ENC\[[^\]]+\])"`).FindSubmatch(data)
+	if match == nil {
+		t.Fatalf("再次写回内容未加密:\n%s", data)
+	}
+	if plaintext, err := DecryptValue(string(match[1]), key); err != nil || plaintext != "newer" {
+		t.Errorf("再次重新加密的值错误: %q, %v", plaintext, err)
+	}
+}
+
+// TestUpdateFieldMissingCiphertext 测试文件中找不到原密文时拒绝写回
+func TestUpdateFieldMissingCiphertext(t *testing.T) {
+	provider, key := testKeyProvider(t)
+	sealed, _ := EncryptValue("old", key)
+
+	opts := NewOption()
+	opts.KeyProvider = provider
+	manager := newTestManager[encryptTestConfig](t, "database:\n  password: "+sealed+"\n", opts)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	// 文件被外部改写，原密文不再存在
+	other, _ := EncryptValue("other", key)
+	if err := os.WriteFile(manager.vp.ConfigFileUsed(), []byte("database:\n  password: "+other+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.UpdateField(func(c *encryptTestConfig) { c.Database.Password = "new" }); err == nil {
+		t.Fatal("找不到原密文时期望返回错误")
+	}
+	if cfg, _ := manager.GetConfig(); cfg.Database.Password != "old" {
+		t.Errorf("写回失败后配置不应变化: %q", cfg.Database.Password)
+	}
+}
@@ -14,4 +14,37 @@ var (
 	
 	// ErrInvalidConfigType 无效的配置类型错误
 	ErrInvalidConfigType = errors.New("无效的配置类型")
+
+	// ErrUnknownConfigKey 严格模式下配置中存在未知字段错误
+	ErrUnknownConfigKey = errors.New("配置中存在未知字段")
+
+	// ErrMigrationFailed 配置版本迁移失败错误
+	ErrMigrationFailed = errors.New("配置版本迁移失败")
+
+	// ErrInterpolation 配置变量插值失败错误
+	ErrInterpolation = errors.New("配置变量插值失败")
+
+	// ErrDecryptFailed 配置值解密失败错误
+	ErrDecryptFailed = errors.New("配置值解密失败")
+
+	// ErrSignatureInvalid 配置文件签名校验失败错误
+	ErrSignatureInvalid = errors.New("配置文件签名校验失败")
+
+	// ErrInsecurePermissions 包含敏感字段的配置文件权限不安全错误
+	ErrInsecurePermissions = errors.New("配置文件权限不安全")
+
+	// ErrReloadVetoed 热重载被处理器否决错误
+	ErrReloadVetoed = errors.New("热重载被否决")
+
+	// ErrReloadRolledBack 热重载提交失败并已回滚错误
+	ErrReloadRolledBack = errors.New("热重载提交失败，已回滚")
+
+	// ErrFieldNotFound 配置结构体中不存在指定路径的字段错误
+	ErrFieldNotFound = errors.New("配置字段不存在")
+
+	// ErrPatchInvalid 配置补丁格式无效或应用后的配置无效错误
+	ErrPatchInvalid = errors.New("配置补丁无效")
+
+	// ErrPatchTestFailed 配置补丁的 test 操作不匹配错误
+	ErrPatchTestFailed = errors.New("配置补丁 test 操作不匹配")
 )
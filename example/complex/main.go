@@ -3,40 +3,41 @@ package main
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/kawaiirei0/configx/v2"
 )
 
 // ServerConfig 服务器配置
 type ServerConfig struct {
-	Host           string `mapstructure:"host"`
-	Port           int    `mapstructure:"port"`
-	ReadTimeout    int    `mapstructure:"read_timeout"`
-	WriteTimeout   int    `mapstructure:"write_timeout"`
-	MaxConnections int    `mapstructure:"max_connections"`
+	Host           string        `mapstructure:"host"`
+	Port           int           `mapstructure:"port"`
+	ReadTimeout    time.Duration `mapstructure:"read_timeout"`
+	WriteTimeout   time.Duration `mapstructure:"write_timeout"`
+	MaxConnections int           `mapstructure:"max_connections"`
 }
 
 // DatabaseConfig 数据库配置
 type DatabaseConfig struct {
-	Driver          string `mapstructure:"driver"`
-	Host            string `mapstructure:"host"`
-	Port            int    `mapstructure:"port"`
-	Database        string `mapstructure:"database"`
-	Username        string `mapstructure:"username"`
-	Password        string `mapstructure:"password"`
-	MaxOpenConns    int    `mapstructure:"max_open_conns"`
-	MaxIdleConns    int    `mapstructure:"max_idle_conns"`
-	ConnMaxLifetime int    `mapstructure:"conn_max_lifetime"`
+	Driver          string         `mapstructure:"driver"`
+	Host            string         `mapstructure:"host"`
+	Port            int            `mapstructure:"port"`
+	Database        string         `mapstructure:"database"`
+	Username        string         `mapstructure:"username"`
+	Password        configx.Secret `mapstructure:"password"`
+	MaxOpenConns    int            `mapstructure:"max_open_conns"`
+	MaxIdleConns    int            `mapstructure:"max_idle_conns"`
+	ConnMaxLifetime time.Duration  `mapstructure:"conn_max_lifetime"`
 }
 
 // RedisConfig Redis 配置
 type RedisConfig struct {
-	Host         string `mapstructure:"host"`
-	Port         int    `mapstructure:"port"`
-	Password     string `mapstructure:"password"`
-	DB           int    `mapstructure:"db"`
-	PoolSize     int    `mapstructure:"pool_size"`
-	MinIdleConns int    `mapstructure:"min_idle_conns"`
+	Host         string         `mapstructure:"host"`
+	Port         int            `mapstructure:"port"`
+	Password     configx.Secret `mapstructure:"password"`
+	DB           int            `mapstructure:"db"`
+	PoolSize     int            `mapstructure:"pool_size"`
+	MinIdleConns int            `mapstructure:"min_idle_conns"`
 }
 
 // LoggingConfig 日志配置
@@ -122,8 +123,8 @@ func main() {
 	// 5. 显示配置内容
 	fmt.Println("📋 服务器配置:")
 	fmt.Printf("  地址:         %s:%d\n", config.Server.Host, config.Server.Port)
-	fmt.Printf("  读超时:       %d 秒\n", config.Server.ReadTimeout)
-	fmt.Printf("  写超时:       %d 秒\n", config.Server.WriteTimeout)
+	fmt.Printf("  读超时:       %v\n", config.Server.ReadTimeout)
+	fmt.Printf("  写超时:       %v\n", config.Server.WriteTimeout)
 	fmt.Printf("  最大连接数:   %d\n", config.Server.MaxConnections)
 
 	fmt.Println("\n💾 数据库配置:")
@@ -0,0 +1,93 @@
+package configx
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+)
+
+// PermissionPolicy 配置文件权限检查策略
+// 仅当配置结构体包含 Secret（或 secret 标签）字段时生效，参照 ssh 对私钥文件的要求：
+// 文件不应被同组或其他用户访问，且应属于当前用户
+type PermissionPolicy int
+
+const (
+	// PermissionWarn 权限不安全时通过 Warn 钩子告警，继续加载（默认）
+	PermissionWarn PermissionPolicy = iota
+	// PermissionRefuse 权限不安全时拒绝加载或热重载，返回 ErrInsecurePermissions
+	PermissionRefuse
+	// PermissionIgnore 不检查文件权限
+	PermissionIgnore
+)
+
+const (
+	// secureFileMode 包含敏感字段的配置文件默认权限
+	secureFileMode os.FileMode = 0600
+	// defaultFileMode 普通配置文件默认权限
+	defaultFileMode os.FileMode = 0644
+)
+
+// hasSecretFields 判断配置类型是否包含敏感字段
+func (m *Manager[T]) hasSecretFields() bool {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	return containsSecret(t) || containsSecretTag(indirectType(t), make(map[reflect.Type]bool))
+}
+
+// containsSecretTag 递归检查结构体中是否存在 `secret:"true"` 标签的字段
+func containsSecretTag(t reflect.Type, visited map[reflect.Type]bool) bool {
+	if t.Kind() != reflect.Struct || visited[t] {
+		return false
+	}
+	visited[t] = true
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if isSecretField(field) || containsSecretTag(indirectType(field.Type), visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// configFileMode 创建默认配置文件时使用的权限
+// 优先使用 Option.FileMode，否则包含敏感字段的配置使用 0600，其他使用 0644
+func (m *Manager[T]) configFileMode(opts *Option) os.FileMode {
+	if opts.FileMode != 0 {
+		return opts.FileMode
+	}
+	if m.hasSecretFields() {
+		return secureFileMode
+	}
+	return defaultFileMode
+}
+
+// checkFilePermissions 检查包含敏感字段的配置文件权限
+// 返回值：
+//
+//	[]HookContext: PermissionWarn 策略下的告警
+//	error: PermissionRefuse 策略下权限不安全时返回 ErrInsecurePermissions
+func (m *Manager[T]) checkFilePermissions(file string, policy PermissionPolicy) ([]HookContext, error) {
+	if policy == PermissionIgnore || !m.hasSecretFields() {
+		return nil, nil
+	}
+	info, err := os.Stat(file)
+	if err != nil {
+		// 文件不存在等错误交由读取配置时报告
+		return nil, nil
+	}
+	problem := insecureFileProblem(info)
+	if problem == "" {
+		return nil, nil
+	}
+	if policy == PermissionRefuse {
+		return nil, fmt.Errorf("%w: %s %s", ErrInsecurePermissions, file, problem)
+	}
+	return []HookContext{{
+		Pattern: Warn,
+		Message: fmt.Sprintf("[config] 配置文件 %s 包含敏感字段，但%s", file, problem),
+		Event:   EventInsecurePermissions,
+		File:    file,
+	}}, nil
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package configx
+
+import "os"
+
+// insecureFileProblem 非 Unix 平台的权限位不具备同等含义，不做检查
+func insecureFileProblem(info os.FileInfo) string {
+	return ""
+}
@@ -0,0 +1,89 @@
+//go:build unix
+
+package configx
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type permissionTestConfig struct {
+	User     string `mapstructure:"user"`
+	Password Secret `mapstructure:"password"`
+}
+
+// TestInsecurePermissionWarn 测试默认策略下权限不安全时通过 Warn 钩子告警
+func TestInsecurePermissionWarn(t *testing.T) {
+	manager := newTestManager[permissionTestConfig](t, "user: admin\npassword: p@ss\n", nil)
+	if err := os.Chmod(manager.opts.File(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var warnings []string
+	manager.SetHook(Warn, func(ctx HookContext) {
+		warnings = append(warnings, ctx.Message)
+	})
+
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("Warn 策略下不应拒绝加载: %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "0644") {
+		t.Errorf("期望一条权限告警，实际: %v", warnings)
+	}
+}
+
+// TestInsecurePermissionRefuse 测试 PermissionRefuse 策略下拒绝加载
+func TestInsecurePermissionRefuse(t *testing.T) {
+	opts := NewOption()
+	opts.FilePermission = PermissionRefuse
+	manager := newTestManager[permissionTestConfig](t, "user: admin\npassword: p@ss\n", opts)
+
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("0600 权限应允许加载: %v", err)
+	}
+
+	if err := os.Chmod(opts.File(), 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.LoadConfig(); !errors.Is(err, ErrInsecurePermissions) {
+		t.Fatalf("期望 ErrInsecurePermissions，实际: %v", err)
+	}
+	if manager.config.Password.Reveal() != "p@ss" {
+		t.Error("拒绝加载时应保留原有配置")
+	}
+}
+
+// TestPermissionCheckWithoutSecrets 测试不含敏感字段的配置不检查权限
+func TestPermissionCheckWithoutSecrets(t *testing.T) {
+	opts := NewOption()
+	opts.FilePermission = PermissionRefuse
+	manager := newTestManager[fileSecurityTestConfig](t, "name: public\n", opts)
+	if err := os.Chmod(opts.File(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("不含敏感字段时不应检查权限: %v", err)
+	}
+}
+
+// TestEnsureConfigFileMode 测试生成默认配置文件时的权限
+func TestEnsureConfigFileMode(t *testing.T) {
+	dir := t.TempDir()
+	opts := NewOption()
+	opts.Filepath.Set(OptionString(dir))
+	opts.Filename.Set("secret.yaml")
+	manager := NewManager(permissionTestConfig{}).SetOption(opts)
+	if err := manager.ensureConfigFile(opts); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(filepath.Join(dir, "secret.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("包含敏感字段的配置文件应为 0600，实际 %04o", perm)
+	}
+}
@@ -0,0 +1,20 @@
+//go:build unix
+
+package configx
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// insecureFileProblem 返回文件权限问题的描述，权限安全时返回空字符串
+func insecureFileProblem(info os.FileInfo) string {
+	if perm := info.Mode().Perm(); perm&0077 != 0 {
+		return fmt.Sprintf("权限 %04o 允许同组或其他用户访问（建议 chmod 600）", perm)
+	}
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok && int(stat.Uid) != os.Getuid() {
+		return fmt.Sprintf("文件属于其他用户（uid %d）", stat.Uid)
+	}
+	return ""
+}
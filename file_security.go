@@ -0,0 +1,237 @@
+package configx
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SignatureSuffix 分离签名文件的后缀，如 config.yaml.sig
+const SignatureSuffix = ".sig"
+
+// x25519Info X25519 文件加密的 HKDF info
+const x25519Info = "configx-x25519-aes256gcm"
+
+// FileDecrypter 整个配置文件的解密器
+// 在解析之前对文件内容解密，解密失败时拒绝加载或热重载
+type FileDecrypter interface {
+	DecryptFile(data []byte) ([]byte, error)
+}
+
+// FileDecrypterFunc 函数形式的 FileDecrypter
+type FileDecrypterFunc func(data []byte) ([]byte, error)
+
+// DecryptFile 实现 FileDecrypter 接口
+func (f FileDecrypterFunc) DecryptFile(data []byte) ([]byte, error) {
+	return f(data)
+}
+
+// AESGCMFileDecrypter 使用 AES-256-GCM 对称密钥解密配置文件
+// 文件格式：nonce || ciphertext，可由 EncryptFileAESGCM 生成
+func AESGCMFileDecrypter(provider KeyProvider) FileDecrypter {
+	return FileDecrypterFunc(func(data []byte) ([]byte, error) {
+		key, err := provider.Key()
+		if err != nil {
+			return nil, fmt.Errorf("获取密钥失败: %w", err)
+		}
+		return openAESGCM(key, data)
+	})
+}
+
+// X25519AESGCMFileDecrypter 使用 X25519 私钥解密配置文件
+// 文件格式：ephemeral public key (32) || nonce || ciphertext，可由 EncryptFileX25519AESGCM 生成；
+// 密钥由 X25519 共享密钥经 HKDF-SHA256 派生，使用 AES-256-GCM 加密。
+// 这是 configx 自己的格式，与 age/rage 不兼容：需要读取 age 加密的文件时，
+// 可以用 FileDecrypterFunc 接入 filippo.io/age
+// 参数 provider 返回 32 字节的 X25519 私钥
+func X25519AESGCMFileDecrypter(provider KeyProvider) FileDecrypter {
+	return FileDecrypterFunc(func(data []byte) ([]byte, error) {
+		raw, err := provider.Key()
+		if err != nil {
+			return nil, fmt.Errorf("获取私钥失败: %w", err)
+		}
+		priv, err := ecdh.X25519().NewPrivateKey(raw)
+		if err != nil {
+			return nil, fmt.Errorf("无效的 X25519 私钥: %w", err)
+		}
+		if len(data) < 32 {
+			return nil, fmt.Errorf("密文格式无效")
+		}
+		ephemeral, err := ecdh.X25519().NewPublicKey(data[:32])
+		if err != nil {
+			return nil, fmt.Errorf("无效的临时公钥: %w", err)
+		}
+		shared, err := priv.ECDH(ephemeral)
+		if err != nil {
+			return nil, err
+		}
+		key, err := x25519Key(shared, ephemeral.Bytes(), priv.PublicKey().Bytes())
+		if err != nil {
+			return nil, err
+		}
+		return openAESGCM(key, data[32:])
+	})
+}
+
+// EncryptFileAESGCM 使用 AES-256-GCM 对称密钥加密配置文件内容
+func EncryptFileAESGCM(plaintext, key []byte) ([]byte, error) {
+	return sealAESGCM(key, plaintext)
+}
+
+// EncryptFileX25519AESGCM 使用接收方的 X25519 公钥加密配置文件内容
+// 生成 X25519AESGCMFileDecrypter 使用的格式（不是 age 格式）
+// 参数 recipient 为 32 字节的 X25519 公钥
+func EncryptFileX25519AESGCM(plaintext, recipient []byte) ([]byte, error) {
+	pub, err := ecdh.X25519().NewPublicKey(recipient)
+	if err != nil {
+		return nil, fmt.Errorf("无效的 X25519 公钥: %w", err)
+	}
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := ephemeral.ECDH(pub)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x25519Key(shared, ephemeral.PublicKey().Bytes(), recipient)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := sealAESGCM(key, plaintext)
+	if err != nil {
+		return nil, err
+	}
+	return append(ephemeral.PublicKey().Bytes(), sealed...), nil
+}
+
+// SignFile 使用 ed25519 私钥为配置文件内容生成 base64 编码的分离签名
+// 签名应写入 <配置文件>.sig；文件监听不监听签名文件，更新时先写签名文件、再写配置文件
+func SignFile(data []byte, priv ed25519.PrivateKey) []byte {
+	sig := ed25519.Sign(priv, data)
+	return []byte(base64.StdEncoding.EncodeToString(sig) + "\n")
+}
+
+// verifySignature 校验配置文件的分离签名
+// 签名文件内容可以是原始 64 字节签名，也可以是其 base64 编码
+func verifySignature(file string, data []byte, pub ed25519.PublicKey) error {
+	raw, err := os.ReadFile(file + SignatureSuffix)
+	if err != nil {
+		return fmt.Errorf("%w: 读取签名文件失败: %v", ErrSignatureInvalid, err)
+	}
+	sig := raw
+	if len(raw) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(raw)))
+		if err != nil {
+			return fmt.Errorf("%w: 签名格式无效", ErrSignatureInvalid)
+		}
+		sig = decoded
+	}
+	if len(pub) != ed25519.PublicKeySize || !ed25519.Verify(pub, data, sig) {
+		return fmt.Errorf("%w: %s", ErrSignatureInvalid, file+SignatureSuffix)
+	}
+	return nil
+}
+
+// checkWritable 检查配置文件能否被改写
+// 整个文件加密时无法按文本写回；需要签名时只持有公钥，写回后的文件没有有效签名，之后的加载会失败
+func checkWritable(file string, opts *Option) error {
+	if opts.FileDecrypter != nil {
+		return fmt.Errorf("配置文件 %s 已整体加密，不支持写回", file)
+	}
+	if opts.SignatureKey != nil {
+		return fmt.Errorf("配置文件 %s 需要签名校验，不支持写回", file)
+	}
+	return nil
+}
+
+// readConfig 读取配置文件到 Viper
+// 先检查文件权限；设置了签名公钥或文件解密器时，再校验签名、解密，全部通过后才交给 Viper 解析；
+// 任一步骤失败都不会修改 Viper 中已有的配置
+// 返回值：
+//
+//	[]HookContext: 待触发的告警钩子（如文件权限不安全）
+//	error: 读取、校验或解密失败时返回错误
+func (m *Manager[T]) readConfig() ([]HookContext, error) {
+	opts := m.options()
+	file := m.vp.ConfigFileUsed()
+
+	warnings, err := m.checkFilePermissions(file, opts.FilePermission)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.FileDecrypter == nil && opts.SignatureKey == nil {
+		return warnings, m.vp.ReadInConfig()
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return warnings, err
+	}
+
+	if opts.SignatureKey != nil {
+		if err := verifySignature(file, data, opts.SignatureKey); err != nil {
+			return warnings, err
+		}
+	}
+
+	if opts.FileDecrypter != nil {
+		if data, err = opts.FileDecrypter.DecryptFile(data); err != nil {
+			return warnings, fmt.Errorf("%w: 解密配置文件 %s 失败: %v", ErrDecryptFailed, file, err)
+		}
+	}
+
+	m.vp.SetConfigType(configFileType(file))
+	return warnings, m.vp.ReadConfig(bytes.NewReader(data))
+}
+
+// configFileType 根据文件扩展名推断配置类型，忽略 .enc 等加密后缀
+func configFileType(file string) string {
+	ext := strings.ToLower(filepath.Ext(file))
+	switch ext {
+	case ".enc", ".gpg":
+		ext = strings.ToLower(filepath.Ext(strings.TrimSuffix(file, filepath.Ext(file))))
+	}
+	return strings.TrimPrefix(ext, ".")
+}
+
+// x25519Key 由 ECDH 共享密钥通过 HKDF-SHA256 派生 AES-256 密钥
+// salt 绑定临时公钥与接收方公钥
+func x25519Key(shared, ephemeral, recipient []byte) ([]byte, error) {
+	salt := append(append([]byte(nil), ephemeral...), recipient...)
+	return hkdf.Key(sha256.New, shared, salt, x25519Info, KeySize)
+}
+
+// sealAESGCM 加密并返回 nonce || ciphertext
+func sealAESGCM(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// openAESGCM 解密 nonce || ciphertext
+func openAESGCM(key, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("密文格式无效")
+	}
+	return gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+}
@@ -0,0 +1,132 @@
+package configx
+
+import (
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type fileSecurityTestConfig struct {
+	Name string `mapstructure:"name"`
+}
+
+// TestSignatureVerification 测试分离签名校验，签名无效时保留原有配置
+func TestSignatureVerification(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	content := []byte("name: signed\n")
+
+	opts := NewOption()
+	opts.SignatureKey = pub
+	manager := newTestManager[fileSecurityTestConfig](t, string(content), opts)
+	file := opts.File()
+	if err := os.WriteFile(file+SignatureSuffix, SignFile(content, priv), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+
+	// 篡改文件内容但不更新签名
+	if err := os.WriteFile(file, []byte("name: tampered\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.LoadConfig(); !errors.Is(err, ErrSignatureInvalid) {
+		t.Fatalf("期望 ErrSignatureInvalid，实际: %v", err)
+	}
+	if manager.config.Name != "signed" {
+		t.Errorf("签名无效时应保留原有配置: %q", manager.config.Name)
+	}
+}
+
+// TestSignedFileRejectsWrite 测试需要签名的配置文件拒绝写回，文件与配置保持不变
+func TestSignedFileRejectsWrite(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	content := []byte("name: signed\n")
+
+	opts := NewOption()
+	opts.SignatureKey = pub
+	manager := newTestManager[fileSecurityTestConfig](t, string(content), opts)
+	file := opts.File()
+	if err := os.WriteFile(file+SignatureSuffix, SignFile(content, priv), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+
+	if err := manager.UpdateField(func(c *fileSecurityTestConfig) { c.Name = "changed" }); err == nil {
+		t.Error("UpdateField 应拒绝写回需要签名的文件")
+	}
+	if err := manager.Set("name", "changed"); err == nil {
+		t.Error("Set 应拒绝写回需要签名的文件")
+	}
+	if _, err := manager.ApplyPatch([]byte(`{"name": "changed"}`), MergePatch); err == nil {
+		t.Error("ApplyPatch 应拒绝写回需要签名的文件")
+	}
+	if data, _ := os.ReadFile(file); string(data) != string(content) {
+		t.Errorf("配置文件不应被改写:\n%s", data)
+	}
+	if manager.config.Name != "signed" {
+		t.Errorf("写回被拒绝时配置应保持不变: %q", manager.config.Name)
+	}
+	if err := manager.LoadConfig(); err != nil {
+		t.Errorf("签名应仍然有效: %v", err)
+	}
+}
+
+// TestX25519FileDecryption 测试整个配置文件的 X25519 解密
+func TestX25519FileDecryption(t *testing.T) {
+	priv, _ := ecdh.X25519().GenerateKey(rand.Reader)
+	sealed, err := EncryptFileX25519AESGCM([]byte("name: encrypted\n"), priv.PublicKey().Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml.enc"), sealed, 0600); err != nil {
+		t.Fatal(err)
+	}
+	opts := NewOption()
+	opts.Filepath.Set(OptionString(dir))
+	opts.Filename.Set("config.yaml.enc")
+	opts.FileDecrypter = X25519AESGCMFileDecrypter(KeyProviderFunc(func() ([]byte, error) { return priv.Bytes(), nil }))
+	manager := NewManager(fileSecurityTestConfig{}).SetOption(opts)
+
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	if manager.config.Name != "encrypted" {
+		t.Errorf("解密结果错误: %q", manager.config.Name)
+	}
+
+	other, _ := ecdh.X25519().GenerateKey(rand.Reader)
+	opts.FileDecrypter = X25519AESGCMFileDecrypter(KeyProviderFunc(func() ([]byte, error) { return other.Bytes(), nil }))
+	if err := manager.LoadConfig(); !errors.Is(err, ErrDecryptFailed) {
+		t.Errorf("密钥错误时期望 ErrDecryptFailed，实际: %v", err)
+	}
+}
+
+// TestAESGCMFileDecryption 测试整个配置文件的 AES-GCM 解密
+func TestAESGCMFileDecryption(t *testing.T) {
+	encoded, _ := GenerateKey()
+	key, _ := ParseKey(encoded)
+	sealed, err := EncryptFileAESGCM([]byte("name: aes\n"), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := NewOption()
+	opts.FileDecrypter = AESGCMFileDecrypter(KeyProviderFunc(func() ([]byte, error) { return key, nil }))
+	manager := newTestManager[fileSecurityTestConfig](t, string(sealed), opts)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	if manager.config.Name != "aes" {
+		t.Errorf("解密结果错误: %q", manager.config.Name)
+	}
+}
@@ -1,5 +1,12 @@
 package configx
 
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
 // HookPattern 钩子级别类型
 type HookPattern int
 
@@ -18,11 +25,126 @@ const (
 	HookIndex
 )
 
+// EventKind 钩子事件类型
+type EventKind string
+
+const (
+	// EventInit 开始初始化
+	EventInit EventKind = "init"
+	// EventFileCreated 生成了默认配置文件
+	EventFileCreated EventKind = "file_created"
+	// EventLoaded 配置文件加载成功
+	EventLoaded EventKind = "loaded"
+	// EventLoadFailed 配置文件读取失败
+	EventLoadFailed EventKind = "load_failed"
+	// EventFileChanged 检测到配置文件变更
+	EventFileChanged EventKind = "file_changed"
+	// EventReloadTriggered 信号或控制文件触发了重载
+	EventReloadTriggered EventKind = "reload_triggered"
+	// EventReloadOK 配置热重载成功
+	EventReloadOK EventKind = "reload_ok"
+	// EventReloadFailed 配置热重载失败，保持原有配置
+	EventReloadFailed EventKind = "reload_failed"
+	// EventReloadSkipped 定时重载时配置文件内容未变化，跳过解析
+	EventReloadSkipped EventKind = "reload_skipped"
+	// EventValidationFailed 配置解析或校验失败
+	EventValidationFailed EventKind = "validation_failed"
+	// EventDeprecatedKey 配置文件中使用了弃用字段
+	EventDeprecatedKey EventKind = "deprecated_key"
+	// EventMigrated 配置版本已迁移
+	EventMigrated EventKind = "migrated"
+	// EventFileWritten 配置文件已被改写（迁移写回、SyncFile）
+	EventFileWritten EventKind = "file_written"
+	// EventWriteSkipped 字段修改未写回配置文件
+	EventWriteSkipped EventKind = "write_skipped"
+	// EventInsecurePermissions 配置文件权限不安全
+	EventInsecurePermissions EventKind = "insecure_permissions"
+	// EventHookPanic 钩子或回调处理函数发生 panic
+	EventHookPanic EventKind = "hook_panic"
+	// EventHandlerTimeout 配置变更回调执行超时
+	EventHandlerTimeout EventKind = "handler_timeout"
+	// EventComponentFailed 组件应用新配置失败
+	EventComponentFailed EventKind = "component_failed"
+	// EventRestartRequired 配置文件中修改了需要重启才能生效的字段
+	EventRestartRequired EventKind = "restart_required"
+	// EventPatchApplied 配置补丁已应用
+	EventPatchApplied EventKind = "patch_applied"
+	// EventPatchFailed 配置补丁应用失败，保持原有配置
+	EventPatchFailed EventKind = "patch_failed"
+)
+
 // HookContext 钩子上下文
-// 包含钩子触发时的消息和级别信息
+// 包含钩子触发时的消息、级别以及结构化的事件信息
 type HookContext struct {
 	Message string
 	Pattern HookPattern
+
+	// Event 事件类型
+	Event EventKind
+	// Time 事件发生时间（由管理器在触发时填充）
+	Time time.Time
+	// File 相关的配置文件
+	File string
+	// Key 相关的配置字段路径
+	Key string
+	// ChangedKeys 本次重载中值发生变化的配置字段路径
+	ChangedKeys []string
+	// Duration 操作耗时
+	Duration time.Duration
+	// Err 导致事件的错误
+	Err error
+	// Attrs 其他附加属性
+	Attrs []slog.Attr
+}
+
+// LogAttrs 将事件信息转换为 slog 属性，未设置的字段会被省略
+func (ctx HookContext) LogAttrs() []slog.Attr {
+	attrs := make([]slog.Attr, 0, 6+len(ctx.Attrs))
+	if ctx.Event != "" {
+		attrs = append(attrs, slog.String("event", string(ctx.Event)))
+	}
+	if ctx.File != "" {
+		attrs = append(attrs, slog.String("file", ctx.File))
+	}
+	if ctx.Key != "" {
+		attrs = append(attrs, slog.String("key", ctx.Key))
+	}
+	if len(ctx.ChangedKeys) > 0 {
+		attrs = append(attrs, slog.Any("changed_keys", ctx.ChangedKeys))
+	}
+	if ctx.Duration > 0 {
+		attrs = append(attrs, slog.Duration("duration", ctx.Duration))
+	}
+	if ctx.Err != nil {
+		attrs = append(attrs, slog.Any("error", ctx.Err))
+	}
+	return append(attrs, ctx.Attrs...)
+}
+
+// Level 返回钩子级别对应的 slog 级别
+// InitHook 与 Info 对应 slog.LevelInfo
+func (p HookPattern) Level() slog.Level {
+	switch p {
+	case Debug:
+		return slog.LevelDebug
+	case Warn:
+		return slog.LevelWarn
+	case Error:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// SlogHandler 创建将钩子事件写入 slog.Logger 的处理函数
+// 日志级别由 HookContext.Pattern 决定，事件字段作为结构化属性输出
+// 示例：
+//
+//	manager.SetHook(configx.Error, configx.SlogHandler(slog.Default()))
+func SlogHandler(logger *slog.Logger) HookHandlerFunc {
+	return func(ctx HookContext) {
+		logger.LogAttrs(context.Background(), ctx.Pattern.Level(), ctx.Message, ctx.LogAttrs()...)
+	}
 }
 
 // HookHandlerFunc 钩子处理函数类型
@@ -40,8 +162,17 @@ func (h HookHandlerFunc) Exec(ctx HookContext) {
 // Hook 钩子管理器
 // 管理不同级别的钩子处理函数
 // 与泛型 Manager[T] 完全兼容
+// 每个级别包含一个由 SetHook 设置的处理函数，以及任意多个由 AddHook 注册的处理函数
 type Hook struct {
 	Handles [HookIndex]HookHandlerFunc
+	added   [HookIndex][]hookEntry
+	nextID  uint64
+}
+
+// hookEntry AddHook 注册的处理函数
+type hookEntry struct {
+	id      uint64
+	handler HookHandlerFunc
 }
 
 // NewHook 创建新的钩子管理器
@@ -60,6 +191,54 @@ func (hooks *Hook) SetHook(index HookPattern, h HookHandlerFunc) *Hook {
 	return hooks
 }
 
+// AddHook 为指定级别追加一个钩子处理函数
+// 返回值：
+//   uint64: 注册编号，用于 RemoveHook
+func (hooks *Hook) AddHook(index HookPattern, h HookHandlerFunc) uint64 {
+	hooks.nextID++
+	hooks.added[index] = append(hooks.added[index], hookEntry{id: hooks.nextID, handler: h})
+	return hooks.nextID
+}
+
+// RemoveHook 移除 AddHook 注册的钩子处理函数
+// 返回值：
+//   bool: 是否找到并移除
+func (hooks *Hook) RemoveHook(index HookPattern, id uint64) bool {
+	entries := hooks.added[index]
+	for i, entry := range entries {
+		if entry.id == id {
+			// 复制而不是原地修改，避免影响正在执行的快照
+			hooks.added[index] = append(append([]hookEntry(nil), entries[:i]...), entries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// handlers 返回指定级别的全部处理函数快照
+// SetHook 设置的处理函数最先执行，其后按注册顺序执行 AddHook 注册的处理函数
+func (hooks *Hook) handlers(index HookPattern) []HookHandlerFunc {
+	list := make([]HookHandlerFunc, 0, len(hooks.added[index])+1)
+	if hooks.Handles[index] != nil {
+		list = append(list, hooks.Handles[index])
+	}
+	for _, entry := range hooks.added[index] {
+		list = append(list, entry.handler)
+	}
+	return list
+}
+
+// HookHandle AddHook 返回的注册句柄
+type HookHandle struct {
+	once   sync.Once
+	remove func()
+}
+
+// Remove 移除对应的钩子处理函数，可以重复调用
+func (h *HookHandle) Remove() {
+	h.once.Do(h.remove)
+}
+
 // SetHook is deprecated - use Manager.hooks.SetHook instead
 // Global singleton removed due to Go generics limitations
 // func SetHook(index HookPattern, h HookHandlerFunc) *Hook {
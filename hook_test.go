@@ -0,0 +1,145 @@
+package configx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSlogHandler 测试钩子事件以结构化属性写入 slog
+func TestSlogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	SlogHandler(logger)(HookContext{
+		Message:     "[config] 配置重新加载成功",
+		Pattern:     Warn,
+		Event:       EventReloadOK,
+		File:        "config.yaml",
+		ChangedKeys: []string{"database.host"},
+		Duration:    time.Millisecond,
+		Err:         errors.New("boom"),
+	})
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("解析日志失败: %v", err)
+	}
+	if record["level"] != "WARN" {
+		t.Errorf("日志级别错误: %v", record["level"])
+	}
+	if record["event"] != "reload_ok" || record["file"] != "config.yaml" || record["error"] != "boom" {
+		t.Errorf("日志属性错误: %v", record)
+	}
+	if keys, _ := record["changed_keys"].([]any); len(keys) != 1 || keys[0] != "database.host" {
+		t.Errorf("changed_keys 错误: %v", record["changed_keys"])
+	}
+}
+
+// TestSetLoggerEvents 测试加载过程中产生的结构化事件
+func TestSetLoggerEvents(t *testing.T) {
+	var buf bytes.Buffer
+	manager := newTestManager[aliasTestConfig](t, "db:\n  hostname: localhost\n", nil)
+	manager.Alias("db.hostname", "database.host")
+	manager.SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{"level=WARN", "event=deprecated_key", "key=db.hostname", "replacement=database.host"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("日志中缺少 %q: %s", want, out)
+		}
+	}
+}
+
+// TestChangedKeys 测试按配置字段路径比较配置
+func TestChangedKeys(t *testing.T) {
+	type server struct {
+		Host    string        `mapstructure:"host"`
+		Timeout time.Duration `mapstructure:"timeout"`
+	}
+	type config struct {
+		Server server            `mapstructure:"server"`
+		Labels map[string]string `mapstructure:"labels"`
+		Tags   []string          `mapstructure:"tags"`
+	}
+
+	oldCfg := config{Server: server{Host: "a", Timeout: time.Second}, Labels: map[string]string{"env": "dev"}, Tags: []string{"x"}}
+	newCfg := config{Server: server{Host: "b", Timeout: time.Second}, Labels: map[string]string{"env": "dev", "team": "core"}, Tags: []string{"y"}}
+
+	got := strings.Join(changedKeys(oldCfg, newCfg), ",")
+	if want := "labels.team,server.host,tags"; got != want {
+		t.Errorf("changedKeys = %q, 期望 %q", got, want)
+	}
+}
+
+// TestAddHookOrderAndRemove 测试多个处理函数按注册顺序执行并可移除
+func TestAddHookOrderAndRemove(t *testing.T) {
+	manager := NewManager(struct{}{})
+	var calls []string
+
+	manager.SetHook(Info, func(ctx HookContext) { calls = append(calls, "set") })
+	first := manager.AddHook(Info, func(ctx HookContext) { calls = append(calls, "first") })
+	manager.AddHook(Info, func(ctx HookContext) { calls = append(calls, "second") })
+
+	manager.executeHook(Info, HookContext{Message: "test"})
+	if got := strings.Join(calls, ","); got != "set,first,second" {
+		t.Errorf("执行顺序错误: %s", got)
+	}
+
+	calls = nil
+	first.Remove()
+	first.Remove()
+	manager.executeHook(Info, HookContext{Message: "test"})
+	if got := strings.Join(calls, ","); got != "set,second" {
+		t.Errorf("移除后执行结果错误: %s", got)
+	}
+}
+
+// TestHookPanicRecovery 测试处理函数 panic 被恢复并通过 Error 钩子报告
+func TestHookPanicRecovery(t *testing.T) {
+	manager := NewManager(struct{}{})
+	var after bool
+	var reported HookContext
+
+	manager.AddHook(Info, func(ctx HookContext) { panic("bad handler") })
+	manager.AddHook(Info, func(ctx HookContext) { after = true })
+	manager.AddHook(Error, func(ctx HookContext) { reported = ctx })
+	manager.AddHook(Error, func(ctx HookContext) { panic("bad error handler") })
+
+	manager.executeHook(Info, HookContext{Event: EventReloadOK})
+	if !after {
+		t.Error("panic 后应继续执行后续处理函数")
+	}
+	if reported.Event != EventHookPanic || reported.Err == nil || !strings.Contains(reported.Err.Error(), "bad handler") {
+		t.Errorf("panic 未通过 Error 钩子报告: %+v", reported)
+	}
+}
+
+// TestSetLoggerKeepsHooks 测试 SetLogger 不覆盖已有钩子，再次调用时替换 logger
+func TestSetLoggerKeepsHooks(t *testing.T) {
+	var first, second bytes.Buffer
+	manager := newTestManager[aliasTestConfig](t, "db:\n  hostname: localhost\n", nil)
+	manager.Alias("db.hostname", "database.host")
+
+	var warned int
+	manager.SetHook(Warn, func(HookContext) { warned++ })
+	manager.SetLogger(slog.New(slog.NewTextHandler(&first, nil)))
+	manager.SetLogger(slog.New(slog.NewTextHandler(&second, nil)))
+
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if warned == 0 {
+		t.Error("SetLogger 不应覆盖 SetHook 设置的处理函数")
+	}
+	if first.Len() != 0 || !strings.Contains(second.String(), "event=deprecated_key") {
+		t.Errorf("再次调用 SetLogger 应替换之前的 logger:\n%s\n%s", first.String(), second.String())
+	}
+}
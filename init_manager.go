@@ -41,6 +41,7 @@ func (m *Manager[T]) Init(handles ...HandlerFunc) error {
 	// hook init
 	m.executeHook(InitHook, HookContext{
 		Message: "开始初始化",
+		Event:   EventInit,
 	})
 
 	// setting debouncedur and file path (线程安全地读取 opts)
@@ -56,32 +57,63 @@ func (m *Manager[T]) Init(handles ...HandlerFunc) error {
 	if err := m.ensureConfigFile(opts); err != nil {
 		m.executeHook(Error, HookContext{
 			Message: fmt.Sprintf("[config] 创建默认配置文件失败: %v", err),
+			Event:   EventLoadFailed,
+			File:    inFile,
+			Err:     err,
 		})
 		return err
 	}
 
-	// 读取配置文件
-	if err := m.vp.ReadInConfig(); err != nil {
+	// 读取配置文件（哈希在读取前计算，供定时重载判断内容是否变化）
+	hash, _ := fileHash(inFile)
+	warnings, err := m.readConfig()
+	m.executeHooks(warnings)
+	if err != nil {
 		m.executeHook(Error, HookContext{
 			Message: fmt.Sprintf("[config] 加载配置失败: %v", err),
+			Event:   EventLoadFailed,
+			File:    inFile,
+			Err:     err,
 		})
 		return err
 	}
 
 	m.executeHook(Info, HookContext{
 		Message: fmt.Sprintf("[config] 已加载配置文件: %s", m.vp.ConfigFileUsed()),
+		Event:   EventLoaded,
+		File:    m.vp.ConfigFileUsed(),
 	})
 
 	// 解析配置到结构体
 	if err := m.Unmarshal(); err != nil {
 		m.executeHook(Error, HookContext{
 			Message: fmt.Sprintf("[config] 解析配置到结构体失败 Error: %s", err.Error()),
+			Event:   EventLoadFailed,
+			File:    inFile,
+			Err:     err,
 		})
 		return err
 	}
+	m.storeAppliedHash(hash)
 
-	// 监听配置变更，传递回调函数
-	m.monitorConfigChanges(handles)
+	// 注册回调函数并监听配置变更
+	for _, handle := range handles {
+		m.OnChange(handle)
+	}
+	if !opts.DisableWatch {
+		m.monitorConfigChanges()
+	}
+
+	// 启动信号与控制文件触发器
+	if err := m.startTriggers(opts); err != nil {
+		m.executeHook(Error, HookContext{
+			Message: fmt.Sprintf("[config] 启动重载触发器失败: %v", err),
+			Event:   EventLoadFailed,
+			Err:     err,
+		})
+		return err
+	}
+	m.startScheduler(opts)
 
 	// 验证配置通过
 	m.validateConfig(true)
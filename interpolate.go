@@ -0,0 +1,213 @@
+package configx
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 插值表达式前缀
+const (
+	interpolateEnvPrefix  = "env:"
+	interpolateFilePrefix = "file:"
+	interpolateFallback   = ":-"
+)
+
+// interpolator 配置值插值器
+// 支持的表达式：
+//
+//	${NAME}           环境变量（名称中不含 "." 与 "["）
+//	${env:NAME}       显式引用环境变量
+//	${file:/path}     文件内容（去除末尾换行）
+//	${database.host}  引用其他配置字段（名称中含 "." 或 "["）
+//	${NAME:-default}  变量未定义或为空时使用默认值（默认值中可以继续使用插值）
+//	$${...}           转义，输出字面量 ${...}
+type interpolator struct {
+	settings  map[string]any
+	done      map[string]bool // 已解析完成的字段
+	resolving []string        // 正在解析的字段（用于检测循环引用）
+	templated []string        // 原始值包含插值表达式的字段
+	evaluated int             // 已计算的插值表达式数量（不含 $${...} 转义）
+	errs      []error
+}
+
+// interpolateSettings 对配置映射中的字符串值执行插值（直接修改 settings）
+// 返回值：
+//
+//	[]string: 原始值包含插值表达式的字段路径（按字母排序）
+//	error: 存在无法解析的变量或循环引用时返回错误，包含全部问题及其字段路径
+func interpolateSettings(settings map[string]any) ([]string, error) {
+	in := &interpolator{settings: settings, done: make(map[string]bool)}
+	in.walk(settings, "")
+	sort.Strings(in.templated)
+	if len(in.errs) > 0 {
+		return in.templated, fmt.Errorf("%w:\n%w", ErrInterpolation, errors.Join(in.errs...))
+	}
+	return in.templated, nil
+}
+
+// walk 递归遍历配置映射
+func (in *interpolator) walk(value any, path string) {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, item := range v {
+			in.walk(item, joinKeyPath(path, k))
+		}
+	case []any:
+		for i, item := range v {
+			in.walk(item, path+"["+strconv.Itoa(i)+"]")
+		}
+	case string:
+		in.resolveKey(path)
+	}
+}
+
+// resolveKey 解析指定字段的值并写回配置映射
+// 返回值：
+//
+//	any: 解析后的值
+//	bool: 解析是否成功
+func (in *interpolator) resolveKey(path string) (any, bool) {
+	value, ok := lookupSetting(in.settings, path)
+	if !ok {
+		return nil, false
+	}
+	str, isString := value.(string)
+	if !isString || in.done[path] {
+		return value, true
+	}
+
+	for i, p := range in.resolving {
+		if p == path {
+			cycle := append(append([]string(nil), in.resolving[i:]...), path)
+			in.errs = append(in.errs, fmt.Errorf("%s: 检测到循环引用 %s", path, strings.Join(cycle, " -> ")))
+			return nil, false
+		}
+	}
+
+	in.resolving = append(in.resolving, path)
+	evaluated := in.evaluated
+	result, ok := in.expand(str, path)
+	in.resolving = in.resolving[:len(in.resolving)-1]
+
+	// 只有展开了插值表达式的字段才记录为模板，仅包含 $${...} 转义的值按普通字段写回
+	if in.evaluated > evaluated {
+		in.templated = append(in.templated, path)
+	}
+
+	in.done[path] = true
+	if !ok {
+		return nil, false
+	}
+	storeSetting(in.settings, path, result)
+	return result, true
+}
+
+// expand 展开字符串中的全部插值表达式
+func (in *interpolator) expand(s, path string) (string, bool) {
+	var b strings.Builder
+	ok := true
+	for {
+		i := strings.Index(s, "${")
+		if i < 0 {
+			b.WriteString(s)
+			return b.String(), ok
+		}
+		// $${ 转义为字面量 ${
+		if i > 0 && s[i-1] == '$' {
+			b.WriteString(s[:i-1])
+			end := matchBrace(s, i+2)
+			if end < 0 {
+				b.WriteString(s[i:])
+				return b.String(), ok
+			}
+			b.WriteString(s[i : end+1])
+			s = s[end+1:]
+			continue
+		}
+
+		b.WriteString(s[:i])
+		end := matchBrace(s, i+2)
+		if end < 0 {
+			in.errs = append(in.errs, fmt.Errorf("%s: 插值表达式缺少右括号 %q", path, s[i:]))
+			return "", false
+		}
+		in.evaluated++
+		value, resolved := in.evaluate(s[i+2:end], path)
+		if !resolved {
+			ok = false
+		}
+		b.WriteString(value)
+		s = s[end+1:]
+	}
+}
+
+// evaluate 计算单个插值表达式（不含 ${ 与 }）
+func (in *interpolator) evaluate(expr, path string) (string, bool) {
+	name, fallback, hasFallback := strings.Cut(expr, interpolateFallback)
+
+	value, found := in.lookup(name, path)
+	if found && value != "" {
+		return value, true
+	}
+	if hasFallback {
+		return in.expand(fallback, path)
+	}
+	if found {
+		return value, true
+	}
+	in.errs = append(in.errs, fmt.Errorf("%s: 无法解析变量 ${%s}", path, name))
+	return "", false
+}
+
+// lookup 按表达式类型查找变量值
+func (in *interpolator) lookup(name, path string) (string, bool) {
+	switch {
+	case strings.HasPrefix(name, interpolateFilePrefix):
+		data, err := os.ReadFile(strings.TrimPrefix(name, interpolateFilePrefix))
+		if err != nil {
+			return "", false
+		}
+		return strings.TrimRight(string(data), "\r\n"), true
+	case strings.HasPrefix(name, interpolateEnvPrefix):
+		return os.LookupEnv(strings.TrimPrefix(name, interpolateEnvPrefix))
+	case strings.ContainsAny(name, ".["):
+		ref := strings.ToLower(name)
+		if _, exists := lookupSetting(in.settings, ref); !exists {
+			return "", false
+		}
+		value, ok := in.resolveKey(ref)
+		if !ok {
+			// 被引用字段自身解析失败，错误已在该字段上报告
+			return "", true
+		}
+		return fmt.Sprint(value), true
+	default:
+		return os.LookupEnv(name)
+	}
+}
+
+// escapeInterpolation 将字面量 ${ 转义为 $${，使写回的文本在下次加载时不被展开
+func escapeInterpolation(s string) string {
+	return strings.ReplaceAll(s, "${", "$${")
+}
+
+// matchBrace 从 start 开始查找与 ${ 匹配的右括号位置，支持嵌套
+func matchBrace(s string, start int) int {
+	depth := 1
+	for i := start; i < len(s); i++ {
+		switch {
+		case s[i] == '{' && i > 0 && s[i-1] == '$':
+			depth++
+		case s[i] == '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
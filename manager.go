@@ -2,7 +2,11 @@ package configx
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"reflect"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -11,19 +15,64 @@ import (
 )
 
 type Manager[T any] struct {
-	config              *T            // 泛型配置对象
-	vp                  *viper.Viper  // Viper 实例
-	rwMutex             sync.RWMutex  // 读写锁（保护 config）
-	hookMutex           sync.RWMutex  // 读写锁（保护 hooks）
-	optsMutex           sync.Mutex    // 互斥锁（保护 opts 和 optsInit）
-	lastChangeNano      atomic.Int64  // 上次触发时间的纳秒时间戳（用于防抖）
-	debounceDur         time.Duration // 防抖间隔（只在初始化时设置，之后只读）
-	hooks               *Hook         // hook
-	pathName            string        // 配置文件
-	opts                *Option       // 设置选项
-	optsInit            bool          // 初始化选项
-	validateConfigValue bool          // 验证
-	defaultConfig       any           // default config
+	config              *T                                // 泛型配置对象
+	vp                  *viper.Viper                      // Viper 实例
+	rwMutex             sync.RWMutex                      // 读写锁（保护 config）
+	hookMutex           sync.RWMutex                      // 读写锁（保护 hooks）
+	optsMutex           sync.Mutex                        // 互斥锁（保护 opts 和 optsInit）
+	lastChangeNano      atomic.Int64                      // 上次触发时间的纳秒时间戳（用于防抖）
+	trailingPending     atomic.Bool                       // 防抖窗口结束时是否已安排补发的重载
+	trailingFunc        atomic.Pointer[func()]            // 窗口结束时补发的重载（窗口内最后一次触发）
+	debounceDur         time.Duration                     // 防抖间隔（只在初始化时设置，之后只读）
+	hooks               *Hook                             // hook
+	loggerMutex         sync.Mutex                        // 互斥锁（保护 loggerHooks）
+	loggerHooks         []*HookHandle                     // SetLogger 注册的钩子
+	pathName            string                            // 配置文件
+	opts                *Option                           // 设置选项
+	optsInit            bool                              // 初始化选项
+	validateConfigValue bool                              // 验证
+	defaultConfig       any                               // default config
+	aliasMutex          sync.RWMutex                      // 读写锁（保护 aliases）
+	aliases             []keyAlias                        // 弃用字段别名
+	migrationMutex      sync.RWMutex                      // 读写锁（保护 migrations 和 migrationErr）
+	migrations          []migration                       // 版本迁移
+	migrationErr        error                             // Migrate 注册时记录的错误
+	appliedDefaults     atomic.Pointer[[]string]          // 当前配置中由 default 标签填充的字段
+	decodeMutex         sync.RWMutex                      // 读写锁（保护 decodeHooks）
+	decodeHooks         []DecodeHook                      // 自定义解码钩子
+	templateKeys        atomic.Pointer[[]string]          // 原始值包含插值表达式的字段
+	encryptedKeys       atomic.Pointer[map[string]string] // 加密字段路径到原始密文的映射
+	reloadMutex         sync.RWMutex                      // 读写锁（保护 reloadHandlers）
+	reloadHandlers      []reloadEntry[T]                  // 两阶段热重载处理器
+	reloadNextID        uint64                            // 下一个处理器注册编号
+	handlerMutex        sync.RWMutex                      // 读写锁（保护 changeHandlers）
+	changeHandlers      []*changeHandler                  // 配置变更回调（按优先级排序）
+	handlerNextID       uint64                            // 下一个回调注册编号
+	changeSeq           atomic.Uint64                     // 配置变更事件序号
+	publishMutex        sync.Mutex                        // 互斥锁（保护 publishQueue 和 publishing）
+	publishQueue        []pendingChange[T]                // 等待分发的配置变更
+	publishing          bool                              // 是否有调用方正在分发变更
+	componentMutex      sync.RWMutex                      // 读写锁（保护 components）
+	components          []*component[T]                   // 可热重载组件（按注册顺序）
+	restartMutex        sync.RWMutex                      // 读写锁（保护 restartKeys）
+	restartKeys         []string                          // RequireRestart 注册的重启字段
+	pendingRestart      atomic.Pointer[[]string]          // 等待重启生效的字段
+	reloadLock          sync.Mutex                        // 互斥锁（串行化热重载与配置写回）
+	writtenHash         atomic.Pointer[string]            // 最近一次写回配置文件的内容哈希（用于忽略自身写入触发的文件监听）
+	appliedHash         atomic.Pointer[string]            // 当前配置对应的配置文件内容哈希（定时重载据此跳过未变化的内容）
+	configInterval      atomic.Pointer[time.Duration]     // ReloadIntervalKey 字段在当前配置中的值（nil 表示未设置）
+	schedulerEnabled    atomic.Bool                       // Init 是否启用了定时重载
+	schedulerRunning    atomic.Bool                       // 定时重载协程是否在运行
+	closed              chan struct{}                     // Close 后关闭
+	closeOnce           sync.Once                         // 保证 Close 只执行一次
+	version             atomic.Uint64                     // 配置版本号
+	etag                atomic.Pointer[string]            // 当前配置内容的哈希
+	versionMutex        sync.Mutex                        // 互斥锁（保护 versionCh）
+	versionCh           chan struct{}                     // 版本变化时关闭的通道
+	watchMutex          sync.Mutex                        // 互斥锁（保护 watchers）
+	watchers            []*watcher[T]                     // Watch 订阅者
+	bindMutex           sync.Mutex                        // 互斥锁（保护 bindings）
+	bindings            []*binding[T]                     // Bind/Field 绑定的值
 }
 
 // Note: Global singleton removed due to Go generics limitations
@@ -40,6 +89,7 @@ func NewManager[T any](defaultConfig T) *Manager[T] {
 		vp:            viper.New(),
 		hooks:         NewHook(),
 		defaultConfig: defaultConfig,
+		closed:        make(chan struct{}),
 	}
 	// 初始化 atomic 字段
 	m.lastChangeNano.Store(0)
@@ -63,13 +113,16 @@ func (m *Manager[T]) GetConfig() (T, error) {
 		return zero, ErrConfigNotInitialized
 	}
 
-	// 如果配置类型实现了 Cloneable 接口，使用自定义克隆
-	if cloneable, ok := any(*m.config).(Cloneable[T]); ok {
+	return cloneValue(*m.config)
+}
+
+// cloneValue 深拷贝配置值
+// 如果类型实现了 Cloneable 接口，使用自定义克隆，否则使用 JSON 序列化深拷贝
+func cloneValue[V any](value V) (V, error) {
+	if cloneable, ok := any(value).(Cloneable[V]); ok {
 		return cloneable.Clone(), nil
 	}
-
-	// 否则使用 JSON 序列化深拷贝
-	return m.jsonDeepCopy()
+	return jsonDeepCopy(value)
 }
 
 // LoadConfig 加载配置文件
@@ -78,53 +131,94 @@ func (m *Manager[T]) GetConfig() (T, error) {
 //
 //	error: 如果读取或解析失败则返回详细错误信息
 func (m *Manager[T]) LoadConfig() error {
+	warnings, err := m.loadConfig()
+	// 在锁外触发钩子，避免钩子中访问配置导致死锁
+	m.executeHooks(warnings)
+	return err
+}
+
+// loadConfig 在写锁保护下读取并解析配置文件
+// 已有配置时，需要重启才能生效的字段与热重载一样保留运行中的值
+// 返回值：
+//
+//	[]HookContext: 加载过程中产生的待触发钩子
+//	error: 读取或解析失败时返回错误
+func (m *Manager[T]) loadConfig() ([]HookContext, error) {
+	// 与热重载、配置写回串行执行
+	m.reloadLock.Lock()
+	defer m.reloadLock.Unlock()
 	m.rwMutex.Lock()
 	defer m.rwMutex.Unlock()
 
 	// 配置 Viper
 	if err := m.setupViper(); err != nil {
-		return fmt.Errorf("配置 Viper 失败: %w", err)
+		return nil, fmt.Errorf("配置 Viper 失败: %w", err)
 	}
 
+	// 在读取前计算文件哈希：读取后文件再被修改时，定时重载会因哈希不同而重新加载
+	hash, _ := fileHash(m.vp.ConfigFileUsed())
+
 	// 读取配置文件
-	if err := m.vp.ReadInConfig(); err != nil {
-		return fmt.Errorf("%w: %s, 错误: %v", ErrConfigFileNotFound, m.vp.ConfigFileUsed(), err)
+	warnings, err := m.readConfig()
+	if err != nil {
+		if errors.Is(err, ErrSignatureInvalid) || errors.Is(err, ErrDecryptFailed) || errors.Is(err, ErrInsecurePermissions) {
+			return warnings, err
+		}
+		return warnings, fmt.Errorf("%w: %s, 错误: %v", ErrConfigFileNotFound, m.vp.ConfigFileUsed(), err)
 	}
 
-	// 创建新的泛型配置实例
-	var newConfig T
-
 	// 解析配置到泛型类型
-	if err := m.vp.Unmarshal(&newConfig); err != nil {
-		return fmt.Errorf("%w: 文件 %s, 错误: %v", ErrConfigParseFailed, m.vp.ConfigFileUsed(), err)
+	newConfig, meta, buildWarnings, err := m.buildConfig()
+	warnings = append(warnings, buildWarnings...)
+	if err != nil {
+		return warnings, fmt.Errorf("%w: 文件 %s, 错误: %w", ErrConfigParseFailed, m.vp.ConfigFileUsed(), err)
+	}
+
+	if m.config != nil {
+		// 需要重启的字段保留运行中的值
+		pending := m.preserveRestartFields(*m.config, newConfig)
+		sort.Strings(pending)
+		m.pendingRestart.Store(&pending)
+		if len(pending) > 0 {
+			warnings = append(warnings, HookContext{
+				Message:     restartMessage(m.vp.ConfigFileUsed(), pending),
+				Pattern:     Warn,
+				Event:       EventRestartRequired,
+				File:        m.vp.ConfigFileUsed(),
+				ChangedKeys: pending,
+			})
+		}
 	}
 
 	// 更新配置
-	m.config = &newConfig
+	m.config = newConfig
+	m.storeMeta(meta)
+	m.storeAppliedHash(hash)
+	m.markApplied(newConfig)
 
-	return nil
+	return warnings, nil
 }
 
-// jsonDeepCopy 使用 JSON 序列化/反序列化实现深拷贝
-// 这是默认的深拷贝方法，当配置类型未实现 Cloneable 接口时使用
-// 返回值：
-//
-//	T: 深拷贝后的配置对象
-//	error: 序列化或反序列化失败时返回错误
-func (m *Manager[T]) jsonDeepCopy() (T, error) {
-	var zero T
+// jsonDeepCopy 使用 JSON 序列化/反序列化深拷贝任意值
+func jsonDeepCopy[V any](value V) (V, error) {
+	var zero V
 	
 	// 序列化
-	data, err := json.Marshal(*m.config)
+	data, err := json.Marshal(value)
 	if err != nil {
 		return zero, fmt.Errorf("序列化配置失败: %w", err)
 	}
 	
 	// 反序列化
-	var copy T
+	var copy V
 	if err := json.Unmarshal(data, &copy); err != nil {
 		return zero, fmt.Errorf("反序列化配置失败: %w", err)
 	}
+
+	// Secret 在 JSON 中被脱敏，需要从原配置恢复真实值
+	if containsSecret(reflect.TypeOf(copy)) {
+		restoreSecrets(reflect.ValueOf(value), reflect.ValueOf(&copy).Elem())
+	}
 	
 	return copy, nil
 }
@@ -162,13 +256,51 @@ func (m *Manager[T]) setupViper() error {
 // 功能：
 //   - 使用读锁保护钩子的读取
 //   - 在锁外执行钩子函数，避免死锁
+//   - 按顺序执行该级别的全部处理函数，单个处理函数 panic 不影响其他处理函数
 func (m *Manager[T]) executeHook(pattern HookPattern, ctx HookContext) {
+	ctx.Pattern = pattern
+	if ctx.Time.IsZero() {
+		ctx.Time = time.Now()
+	}
+
 	m.hookMutex.RLock()
-	handler := m.hooks.Handles[pattern]
+	handlers := m.hooks.handlers(pattern)
 	m.hookMutex.RUnlock()
 
-	if handler != nil {
-		handler(ctx)
+	for _, handler := range handlers {
+		m.runHookHandler(handler, ctx)
+	}
+}
+
+// runHookHandler 执行单个钩子处理函数并恢复 panic
+// 非 Error 级别处理函数的 panic 通过 Error 钩子报告；
+// Error 级别处理函数的 panic 写入 slog.Default()，避免递归
+func (m *Manager[T]) runHookHandler(handler HookHandlerFunc, ctx HookContext) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		err := fmt.Errorf("钩子处理函数 panic: %v", r)
+		if ctx.Pattern == Error {
+			slog.Default().Error("[config] "+err.Error(), slog.String("event", string(ctx.Event)))
+			return
+		}
+		m.executeHook(Error, HookContext{
+			Message: fmt.Sprintf("[config] %v", err),
+			Event:   EventHookPanic,
+			Err:     err,
+			Attrs:   []slog.Attr{slog.String("hook_event", string(ctx.Event))},
+		})
+	}()
+	handler(ctx)
+}
+
+// executeHooks 依次执行一组钩子（线程安全）
+// 每个钩子按其 Pattern 字段分发到对应级别
+func (m *Manager[T]) executeHooks(ctxs []HookContext) {
+	for _, ctx := range ctxs {
+		m.executeHook(ctx.Pattern, ctx)
 	}
 }
 
@@ -189,3 +321,47 @@ func (m *Manager[T]) SetHook(pattern HookPattern, handler HookHandlerFunc) *Mana
 	m.hooks.SetHook(pattern, handler)
 	return m
 }
+
+// AddHook 追加钩子处理函数
+// 与 SetHook 不同，AddHook 不会覆盖已有的处理函数，适合库与应用同时观察配置事件
+// 参数：
+//   pattern: 钩子级别（InitHook, Debug, Info, Warn, Error）
+//   handler: 钩子处理函数
+// 返回值：
+//   *HookHandle: 注册句柄，调用 Remove() 移除该处理函数
+// 功能：
+//   - 同一级别的处理函数按注册顺序执行（SetHook 设置的处理函数最先执行）
+//   - 处理函数 panic 会被恢复并通过 Error 钩子报告，不会影响文件监听
+//   - 线程安全
+func (m *Manager[T]) AddHook(pattern HookPattern, handler HookHandlerFunc) *HookHandle {
+	m.hookMutex.Lock()
+	id := m.hooks.AddHook(pattern, handler)
+	m.hookMutex.Unlock()
+
+	return &HookHandle{remove: func() {
+		m.hookMutex.Lock()
+		defer m.hookMutex.Unlock()
+		m.hooks.RemoveHook(pattern, id)
+	}}
+}
+
+// SetLogger 将全部级别的钩子事件输出到 slog.Logger
+// 通过 AddHook 为每个钩子级别追加 SlogHandler(logger)，不会覆盖 SetHook、AddHook 注册的处理函数；
+// 再次调用时替换之前设置的 logger
+// 返回值：
+//
+//	*Manager[T]: 返回管理器实例以支持链式调用
+func (m *Manager[T]) SetLogger(logger *slog.Logger) *Manager[T] {
+	m.loggerMutex.Lock()
+	defer m.loggerMutex.Unlock()
+	for _, handle := range m.loggerHooks {
+		handle.Remove()
+	}
+	m.loggerHooks = m.loggerHooks[:0]
+
+	handler := SlogHandler(logger)
+	for pattern := InitHook; pattern < HookIndex; pattern++ {
+		m.loggerHooks = append(m.loggerHooks, m.AddHook(pattern, handler))
+	}
+	return m
+}
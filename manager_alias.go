@@ -0,0 +1,173 @@
+package configx
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// keyAlias 弃用字段到新字段的映射
+type keyAlias struct {
+	oldKey string
+	newKey string
+}
+
+// Alias 注册弃用字段别名
+// 加载配置时，弃用字段的值会迁移到新字段，并触发 Warn 钩子提示文件与字段名
+// 参数：
+//
+//	oldKey: 弃用的字段路径，如 "db.host"
+//	newKey: 新的字段路径，如 "database.host"
+//
+// 返回值：
+//
+//	*Manager[T]: 返回管理器实例以支持链式调用
+//
+// 注意：
+//   - 如果文件中同时存在新旧字段，以新字段为准，旧字段被忽略
+//   - 开启 Option.RewriteAliases 后，UpdateField 写回时会将旧字段名改写为新字段名
+func (m *Manager[T]) Alias(oldKey, newKey string) *Manager[T] {
+	m.aliasMutex.Lock()
+	defer m.aliasMutex.Unlock()
+	m.aliases = append(m.aliases, keyAlias{
+		oldKey: strings.ToLower(oldKey),
+		newKey: strings.ToLower(newKey),
+	})
+	return m
+}
+
+// aliasList 线程安全地获取已注册的别名
+func (m *Manager[T]) aliasList() []keyAlias {
+	m.aliasMutex.RLock()
+	defer m.aliasMutex.RUnlock()
+	return append([]keyAlias(nil), m.aliases...)
+}
+
+// applyAliases 将配置映射中的弃用字段迁移到新字段
+// 返回值：
+//
+//	[]HookContext: 每个弃用字段对应一条 Warn 提示
+func (m *Manager[T]) applyAliases(settings map[string]any) []HookContext {
+	var warnings []HookContext
+	file := m.vp.ConfigFileUsed()
+	for _, alias := range m.aliasList() {
+		value, ok := lookupSetting(settings, alias.oldKey)
+		if !ok {
+			continue
+		}
+		deleteSetting(settings, alias.oldKey)
+
+		msg := fmt.Sprintf("[config] 配置文件 %s 中的字段 %s 已弃用，请改用 %s", file, alias.oldKey, alias.newKey)
+		if _, exists := lookupSetting(settings, alias.newKey); exists {
+			msg += "（新字段已存在，旧字段被忽略）"
+		} else {
+			storeSetting(settings, alias.newKey, value)
+		}
+		warnings = append(warnings, HookContext{
+			Message: msg,
+			Pattern: Warn,
+			Event:   EventDeprecatedKey,
+			File:    file,
+			Key:     alias.oldKey,
+			Attrs:   []slog.Attr{slog.String("replacement", alias.newKey)},
+		})
+	}
+	return warnings
+}
+
+// rewriteAliasKeys 将 YAML 内容中的弃用字段改写为新字段名
+// 返回值：
+//
+//	[]byte: 改写后的内容（没有弃用字段时返回原内容）
+//	[]keyAlias: 实际被改写的别名
+//	error: 解析或编码失败时返回错误
+func (m *Manager[T]) rewriteAliasKeys(content []byte) ([]byte, []keyAlias, error) {
+	aliases := m.aliasList()
+	if len(aliases) == 0 {
+		return content, nil, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, nil, fmt.Errorf("解析配置文件失败: %w", err)
+	}
+
+	var rewritten []keyAlias
+	for _, alias := range aliases {
+		if findYAMLNode(&doc, alias.oldKey) == nil {
+			continue
+		}
+		if findYAMLNode(&doc, alias.newKey) != nil {
+			// 新字段已存在时仅移除旧字段，与加载时的优先级保持一致
+			removeYAMLKey(&doc, alias.oldKey)
+		} else {
+			key, value := removeYAMLKey(&doc, alias.oldKey)
+			insertYAMLKey(&doc, alias.newKey, key, value)
+		}
+		rewritten = append(rewritten, alias)
+	}
+	if len(rewritten) == 0 {
+		return content, nil, nil
+	}
+
+	data, err := encodeYAML(&doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("编码配置文件失败: %w", err)
+	}
+	return data, rewritten, nil
+}
+
+// SyncFile 将配置文件中的弃用字段改写为新字段名
+// 仅支持 YAML 格式的配置文件
+// 返回值：
+//
+//	error: 读取、改写或写入失败时返回错误
+func (m *Manager[T]) SyncFile() error {
+	configFile, rewritten, err := m.syncFile()
+	if err != nil {
+		return err
+	}
+
+	for _, alias := range rewritten {
+		m.executeHook(Info, HookContext{
+			Message: fmt.Sprintf("[config] 已将配置文件 %s 中的字段 %s 改写为 %s", configFile, alias.oldKey, alias.newKey),
+			Pattern: Info,
+			Event:   EventFileWritten,
+			File:    configFile,
+			Key:     alias.oldKey,
+			Attrs:   []slog.Attr{slog.String("replacement", alias.newKey)},
+		})
+	}
+	return nil
+}
+
+// syncFile 在写锁保护下改写配置文件
+func (m *Manager[T]) syncFile() (string, []keyAlias, error) {
+	m.rwMutex.Lock()
+	defer m.rwMutex.Unlock()
+
+	configFile := m.vp.ConfigFileUsed()
+	if err := checkWritable(configFile, m.options()); err != nil {
+		return configFile, nil, err
+	}
+	if !isYAMLFile(configFile) {
+		return configFile, nil, fmt.Errorf("仅支持改写 YAML 配置文件: %s", configFile)
+	}
+
+	content, err := os.ReadFile(configFile)
+	if err != nil {
+		return configFile, nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	newContent, rewritten, err := m.rewriteAliasKeys(content)
+	if err != nil || len(rewritten) == 0 {
+		return configFile, nil, err
+	}
+	if err := os.WriteFile(configFile, newContent, 0644); err != nil {
+		return configFile, nil, err
+	}
+	return configFile, rewritten, nil
+}
@@ -0,0 +1,64 @@
+package configx
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+type aliasTestConfig struct {
+	Database struct {
+		Host string `mapstructure:"host"`
+		Port int    `mapstructure:"port"`
+	} `mapstructure:"database"`
+}
+
+// TestAliasMigratesDeprecatedKeys 测试弃用字段迁移并触发 Warn 钩子
+func TestAliasMigratesDeprecatedKeys(t *testing.T) {
+	opts := NewOption()
+	opts.Strict = true
+	manager := newTestManager[aliasTestConfig](t, "db:\n  host: old-host\ndatabase:\n  port: 5432\n", opts)
+
+	var warnings []string
+	manager.Alias("db.host", "database.host").SetHook(Warn, func(ctx HookContext) {
+		warnings = append(warnings, ctx.Message)
+	})
+
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	cfg, _ := manager.GetConfig()
+	if cfg.Database.Host != "old-host" || cfg.Database.Port != 5432 {
+		t.Errorf("弃用字段未迁移: %+v", cfg)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "db.host") || !strings.Contains(warnings[0], "config.yaml") {
+		t.Errorf("Warn 钩子内容错误: %v", warnings)
+	}
+}
+
+// TestSyncFileRewritesAliases 测试 SyncFile 将弃用字段改写为新字段名
+func TestSyncFileRewritesAliases(t *testing.T) {
+	manager := newTestManager[aliasTestConfig](t, "db:\n  host: old-host # 主库\ndatabase:\n  port: 5432\n", nil)
+	manager.Alias("db.host", "database.host")
+
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	if err := manager.SyncFile(); err != nil {
+		t.Fatalf("改写配置文件失败: %v", err)
+	}
+
+	data, _ := os.ReadFile(manager.vp.ConfigFileUsed())
+	content := string(data)
+	if !strings.Contains(content, "host: old-host # 主库") || strings.Contains(content, "db:") {
+		t.Errorf("配置文件未正确改写:\n%s", content)
+	}
+
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("重新加载配置失败: %v", err)
+	}
+	cfg, _ := manager.GetConfig()
+	if cfg.Database.Host != "old-host" {
+		t.Errorf("改写后配置错误: %+v", cfg)
+	}
+}
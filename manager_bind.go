@@ -0,0 +1,258 @@
+package configx
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Value 绑定到配置中某个字段的值
+// Load 是一次原子读取，始终返回最近一次生效（加载、热重载、UpdateField）的配置中的值，
+// 组件可以只持有需要的部分，而不必调用 GetConfig 复制整个配置
+type Value[V any] struct {
+	current atomic.Pointer[V]
+
+	mu       sync.Mutex
+	handlers []valueHandler[V]
+	nextID   uint64
+	pending  [][2]V            // 待通知的变化（旧值, 新值）
+	notify   chan struct{}     // 有新的待通知变化
+	done     chan struct{}     // 绑定解除
+	once     sync.Once         // 保证 Close 只执行一次
+	detach   func()            // 从管理器中移除绑定
+	report   func(HookContext) // 报告回调 panic
+	started  bool              // 回调 goroutine 已启动
+	stopped  bool              // 绑定已解除
+}
+
+// valueHandler Value.OnChange 注册的回调
+type valueHandler[V any] struct {
+	id uint64
+	fn func(oldValue, newValue V)
+}
+
+// binding 管理器一侧的绑定记录
+type binding[T any] struct {
+	refresh func(cfg *T)
+}
+
+// Bind 绑定配置中的一个值
+// selector 在配置生效时被调用（可能持有配置锁），只应读取并返回字段，不能调用管理器的方法，
+// 也不能保留或修改传入的配置指针；selector panic 时保留上一次的值
+// 返回值：
+//
+//	*Value[V]: 绑定的值，调用 Close() 解除绑定
+//
+// 示例：
+//
+//	maxConns := configx.Bind(manager, func(c *AppConfig) int { return c.Server.MaxConnections })
+//	if active >= maxConns.Load() {
+//	    return errTooManyConnections
+//	}
+func Bind[T, V any](m *Manager[T], selector func(c *T) V) *Value[V] {
+	v := &Value[V]{
+		notify: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+		report: func(ctx HookContext) { m.executeHook(Error, ctx) },
+	}
+	b := &binding[T]{refresh: func(cfg *T) {
+		if value, ok := selectValue(selector, cfg); ok {
+			v.store(value)
+		}
+	}}
+
+	m.bindMutex.Lock()
+	m.bindings = append(m.bindings, b)
+	m.bindMutex.Unlock()
+
+	m.rwMutex.RLock()
+	if m.config != nil {
+		// 与并发的配置更新竞争时以更新写入的值为准
+		if value, ok := selectValue(selector, m.config); ok {
+			v.current.CompareAndSwap(nil, &value)
+		}
+	}
+	m.rwMutex.RUnlock()
+
+	v.detach = func() {
+		m.bindMutex.Lock()
+		defer m.bindMutex.Unlock()
+		for i, existing := range m.bindings {
+			if existing == b {
+				m.bindings = append(append([]*binding[T](nil), m.bindings[:i]...), m.bindings[i+1:]...)
+				return
+			}
+		}
+	}
+	go func() {
+		select {
+		case <-m.closed:
+			v.Close()
+		case <-v.done:
+		}
+	}()
+	return v
+}
+
+// Field 按字段路径绑定配置中的一个值
+// 路径格式与 Viper 一致（如 "server.max_connections"、"servers[0].host"），按 mapstructure 标签匹配
+// 返回值：
+//
+//	*Value[V]: 绑定的值
+//	error: 路径不存在时返回 ErrFieldNotFound，字段类型与 V 不一致时返回 ErrInvalidConfigType
+//
+// 示例：
+//
+//	maxConns, err := configx.Field[int](manager, "server.max_connections")
+func Field[V, T any](m *Manager[T], path string) (*Value[V], error) {
+	fieldType, err := fieldPathType(reflect.TypeFor[T](), path)
+	if err != nil {
+		return nil, err
+	}
+	target := reflect.TypeFor[V]()
+	if !fieldType.AssignableTo(target) {
+		return nil, fmt.Errorf("%w: 字段 %s 的类型为 %s，而不是 %s", ErrInvalidConfigType, path, fieldType, target)
+	}
+	return Bind(m, func(c *T) V {
+		var value V
+		if field, ok := lookupField(reflect.ValueOf(c), path); ok {
+			if field.Kind() == reflect.Interface && field.IsNil() {
+				return value
+			}
+			reflect.ValueOf(&value).Elem().Set(field)
+		}
+		return value
+	}), nil
+}
+
+// Load 返回当前生效的值（配置尚未加载时为零值）
+func (v *Value[V]) Load() V {
+	if p := v.current.Load(); p != nil {
+		return *p
+	}
+	var zero V
+	return zero
+}
+
+// OnChange 注册值发生变化时的回调
+// 回调在独立的 goroutine 中按变化顺序执行，不阻塞配置加载与热重载；回调 panic 会被恢复并通过 Error 钩子报告
+// 返回值：
+//
+//	*HookHandle: 注册句柄，调用 Remove() 注销回调
+func (v *Value[V]) OnChange(fn func(oldValue, newValue V)) *HookHandle {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.nextID++
+	id := v.nextID
+	v.handlers = append(v.handlers, valueHandler[V]{id: id, fn: fn})
+	if !v.started && !v.stopped {
+		v.started = true
+		go v.run()
+	}
+
+	return &HookHandle{remove: func() {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+		for i, h := range v.handlers {
+			if h.id == id {
+				v.handlers = append(append([]valueHandler[V](nil), v.handlers[:i]...), v.handlers[i+1:]...)
+				return
+			}
+		}
+	}}
+}
+
+// Close 解除绑定：Load 保持最后的值，不再执行 OnChange 回调
+// 管理器关闭时自动解除
+func (v *Value[V]) Close() {
+	v.once.Do(func() {
+		v.detach()
+		v.mu.Lock()
+		v.stopped = true
+		v.pending = nil
+		v.mu.Unlock()
+		close(v.done)
+	})
+}
+
+// store 更新当前值，值发生变化且注册了回调时加入待通知队列
+func (v *Value[V]) store(value V) {
+	old := v.Load()
+	v.current.Store(&value)
+	if reflect.DeepEqual(old, value) {
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if len(v.handlers) == 0 || v.stopped {
+		return
+	}
+	v.pending = append(v.pending, [2]V{old, value})
+	select {
+	case v.notify <- struct{}{}:
+	default:
+	}
+}
+
+// run 按顺序执行 OnChange 回调
+func (v *Value[V]) run() {
+	for {
+		select {
+		case <-v.done:
+			return
+		case <-v.notify:
+		}
+		for {
+			v.mu.Lock()
+			if len(v.pending) == 0 || v.stopped {
+				v.mu.Unlock()
+				break
+			}
+			change := v.pending[0]
+			v.pending = v.pending[1:]
+			handlers := append([]valueHandler[V](nil), v.handlers...)
+			v.mu.Unlock()
+
+			for _, h := range handlers {
+				v.runHandler(h.fn, change[0], change[1])
+			}
+		}
+	}
+}
+
+// runHandler 执行单个回调并恢复 panic
+func (v *Value[V]) runHandler(fn func(oldValue, newValue V), oldValue, newValue V) {
+	defer func() {
+		if r := recover(); r != nil {
+			err := fmt.Errorf("%v", r)
+			v.report(HookContext{
+				Message: fmt.Sprintf("[config] Value.OnChange 回调 panic: %v", r),
+				Event:   EventHookPanic,
+				Err:     err,
+			})
+		}
+	}()
+	fn(oldValue, newValue)
+}
+
+// refreshBindings 使用新生效的配置更新全部绑定的值
+func (m *Manager[T]) refreshBindings(cfg *T) {
+	m.bindMutex.Lock()
+	bindings := append([]*binding[T](nil), m.bindings...)
+	m.bindMutex.Unlock()
+	for _, b := range bindings {
+		b.refresh(cfg)
+	}
+}
+
+// selectValue 调用 selector 并恢复 panic
+func selectValue[T, V any](selector func(c *T) V, cfg *T) (value V, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			ok = false
+		}
+	}()
+	return selector(cfg), true
+}
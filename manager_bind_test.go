@@ -0,0 +1,105 @@
+package configx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+type bindTestConfig struct {
+	Server struct {
+		MaxConnections int               `mapstructure:"max_connections"`
+		Hosts          []string          `mapstructure:"hosts"`
+		Labels         map[string]string `mapstructure:"labels"`
+	} `mapstructure:"server"`
+}
+
+// TestBindTracksReloads 测试绑定的值跟随热重载与 UpdateField 更新
+func TestBindTracksReloads(t *testing.T) {
+	opts := NewOption()
+	opts.DisableWatch = true
+	manager := newTestManager[bindTestConfig](t, "server:\n  max_connections: 10\n  hosts: [a, b]\n  labels:\n    zone: east\n", opts)
+	t.Cleanup(func() { manager.Close() })
+
+	maxConns := Bind(manager, func(c *bindTestConfig) int { return c.Server.MaxConnections })
+	if maxConns.Load() != 0 {
+		t.Error("未加载时应为零值")
+	}
+	if err := manager.Init(); err != nil {
+		t.Fatal(err)
+	}
+	if maxConns.Load() != 10 {
+		t.Errorf("加载后的值错误: %d", maxConns.Load())
+	}
+
+	host, err := Field[string](manager, "server.hosts[1]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	zone, err := Field[string](manager, "Server.Labels.Zone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if host.Load() != "b" || zone.Load() != "east" {
+		t.Errorf("按路径绑定的值错误: %q %q", host.Load(), zone.Load())
+	}
+
+	changes := make(chan [2]int, 4)
+	maxConns.OnChange(func(oldValue, newValue int) { changes <- [2]int{oldValue, newValue} })
+
+	if err := os.WriteFile(opts.File(), []byte("server:\n  max_connections: 20\n  hosts: [a]\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.Reload(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if maxConns.Load() != 20 || host.Load() != "" || zone.Load() != "" {
+		t.Errorf("重载后的值错误: %d %q %q", maxConns.Load(), host.Load(), zone.Load())
+	}
+
+	if err := manager.UpdateField(func(c *bindTestConfig) { c.Server.MaxConnections = 30 }); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range [][2]int{{10, 20}, {20, 30}} {
+		select {
+		case got := <-changes:
+			if got != want {
+				t.Errorf("OnChange 参数错误: %v，期望 %v", got, want)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("未收到 OnChange 回调")
+		}
+	}
+}
+
+// TestFieldErrors 测试路径不存在与类型不一致
+func TestFieldErrors(t *testing.T) {
+	manager := NewManager(bindTestConfig{})
+	if _, err := Field[int](manager, "server.missing"); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("期望 ErrFieldNotFound，实际: %v", err)
+	}
+	if _, err := Field[int](manager, "server.max_connections.x"); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("期望 ErrFieldNotFound，实际: %v", err)
+	}
+	if _, err := Field[string](manager, "server.max_connections"); !errors.Is(err, ErrInvalidConfigType) {
+		t.Errorf("期望 ErrInvalidConfigType，实际: %v", err)
+	}
+}
+
+// TestValueClose 测试解除绑定后不再更新
+func TestValueClose(t *testing.T) {
+	manager := newTestManager[bindTestConfig](t, "server:\n  max_connections: 1\n", nil)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	value := Bind(manager, func(c *bindTestConfig) int { return c.Server.MaxConnections })
+	value.Close()
+	if err := reloadTestFile(t, manager, "server:\n  max_connections: 2\n"); err != nil {
+		t.Fatal(err)
+	}
+	if value.Load() != 1 {
+		t.Errorf("解除绑定后应保持最后的值: %d", value.Load())
+	}
+}
@@ -0,0 +1,243 @@
+package configx
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// Reloadable 可热重载的长生命周期组件（HTTP 服务、数据库连接池、限流器等）
+// 配置热重载成功后，依赖的配置字段发生变化的组件会按依赖顺序调用 Apply
+type Reloadable[T any] interface {
+	Apply(oldConfig, newConfig T) error
+}
+
+// KeyedReloadable 可选接口：声明组件依赖的配置字段路径
+// 只有这些字段（或其子字段、父字段）发生变化时才调用 Apply；
+// 未实现该接口的组件在任何配置变化时都会被调用
+type KeyedReloadable interface {
+	Keys() []string
+}
+
+// ReloadableFunc 函数形式的 Reloadable
+type ReloadableFunc[T any] func(oldConfig, newConfig T) error
+
+// Apply 实现 Reloadable 接口
+func (f ReloadableFunc[T]) Apply(oldConfig, newConfig T) error {
+	return f(oldConfig, newConfig)
+}
+
+// ComponentError 组件应用新配置失败的错误
+type ComponentError struct {
+	Name string
+	Err  error
+}
+
+// Error 实现 error 接口
+func (e *ComponentError) Error() string {
+	return fmt.Sprintf("组件 %s 应用新配置失败: %v", e.Name, e.Err)
+}
+
+// Unwrap 返回原始错误
+func (e *ComponentError) Unwrap() error {
+	return e.Err
+}
+
+// component 已注册的组件
+type component[T any] struct {
+	name      string
+	reloader  Reloadable[T]
+	dependsOn []string
+}
+
+// Register 注册可热重载组件
+// 参数：
+//
+//	name: 组件名称（唯一，重复注册会替换原组件）
+//	reloader: 组件实现
+//	dependsOn: 依赖的其他组件名称，被依赖的组件先应用；依赖应用失败时本组件被跳过
+//
+// 返回值：
+//
+//	*HookHandle: 注册句柄，调用 Remove() 注销组件
+//
+// 示例：
+//
+//	manager.Register("db", dbPool)
+//	manager.Register("http", configx.ReloadableFunc[AppConfig](func(oldCfg, newCfg AppConfig) error {
+//	    return server.SetTimeouts(newCfg.Server.ReadTimeout, newCfg.Server.WriteTimeout)
+//	}), "db")
+func (m *Manager[T]) Register(name string, reloader Reloadable[T], dependsOn ...string) *HookHandle {
+	m.componentMutex.Lock()
+	defer m.componentMutex.Unlock()
+
+	c := &component[T]{name: name, reloader: reloader, dependsOn: dependsOn}
+	replaced := false
+	for i, existing := range m.components {
+		if existing.name == name {
+			m.components[i] = c
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		m.components = append(m.components, c)
+	}
+
+	return &HookHandle{remove: func() {
+		m.componentMutex.Lock()
+		defer m.componentMutex.Unlock()
+		for i, existing := range m.components {
+			if existing == c {
+				m.components = append(append([]*component[T](nil), m.components[:i]...), m.components[i+1:]...)
+				return
+			}
+		}
+	}}
+}
+
+// applyComponents 将新配置应用到受影响的组件
+// 返回值：
+//
+//	[]string: 成功应用的组件名称（按应用顺序）
+//	[]*ComponentError: 应用失败或被跳过的组件
+func (m *Manager[T]) applyComponents(oldConfig, newConfig T, keys []string) ([]string, []*ComponentError) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	m.componentMutex.RLock()
+	components := append([]*component[T](nil), m.components...)
+	m.componentMutex.RUnlock()
+
+	ordered, cyclic := sortComponents(components)
+	failed := make(map[string]bool)
+	var applied []string
+	var errs []*ComponentError
+
+	for _, c := range cyclic {
+		failed[c.name] = true
+		errs = append(errs, &ComponentError{Name: c.name, Err: fmt.Errorf("组件之间存在循环依赖")})
+	}
+
+	for _, c := range ordered {
+		if dep := firstFailed(c.dependsOn, failed); dep != "" {
+			failed[c.name] = true
+			errs = append(errs, &ComponentError{Name: c.name, Err: fmt.Errorf("依赖的组件 %s 应用失败，已跳过", dep)})
+			continue
+		}
+		if !componentAffected(c.reloader, keys) {
+			continue
+		}
+		if err := applyComponent(c.reloader, oldConfig, newConfig); err != nil {
+			failed[c.name] = true
+			errs = append(errs, &ComponentError{Name: c.name, Err: err})
+			continue
+		}
+		applied = append(applied, c.name)
+	}
+	return applied, errs
+}
+
+// applyComponent 调用组件的 Apply，将 panic 转换为错误，避免单个组件中断热重载
+func applyComponent[T any](reloader Reloadable[T], oldConfig, newConfig T) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("组件 panic: %v", r)
+		}
+	}()
+	return reloader.Apply(oldConfig, newConfig)
+}
+
+// sortComponents 按依赖关系对组件进行拓扑排序，无依赖约束时保持注册顺序
+// 返回值：
+//
+//	[]*component[T]: 排序后的组件
+//	[]*component[T]: 处于循环依赖中（或依赖循环依赖组件）而无法排序的组件
+func sortComponents[T any](components []*component[T]) ([]*component[T], []*component[T]) {
+	known := make(map[string]bool, len(components))
+	for _, c := range components {
+		known[c.name] = true
+	}
+
+	done := make(map[string]bool, len(components))
+	var ordered []*component[T]
+	for len(ordered) < len(components) {
+		progressed := false
+		for _, c := range components {
+			if done[c.name] {
+				continue
+			}
+			ready := true
+			for _, dep := range c.dependsOn {
+				// 未注册的依赖视为已满足
+				if known[dep] && !done[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				done[c.name] = true
+				ordered = append(ordered, c)
+				progressed = true
+				break
+			}
+		}
+		if !progressed {
+			break
+		}
+	}
+
+	var cyclic []*component[T]
+	for _, c := range components {
+		if !done[c.name] {
+			cyclic = append(cyclic, c)
+		}
+	}
+	return ordered, cyclic
+}
+
+// componentAffected 判断组件依赖的配置字段是否发生变化
+func componentAffected(reloader any, keys []string) bool {
+	keyed, ok := reloader.(KeyedReloadable)
+	if !ok {
+		return true
+	}
+	for _, want := range keyed.Keys() {
+		if keysOverlap(strings.ToLower(want), keys) {
+			return true
+		}
+	}
+	return false
+}
+
+// keysOverlap 判断字段路径与变化的字段是否重叠（相同、子字段或父字段）
+func keysOverlap(path string, keys []string) bool {
+	for _, key := range keys {
+		if key == path || strings.HasPrefix(key, path+".") || strings.HasPrefix(path, key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// firstFailed 返回第一个失败的依赖名称，没有时返回空字符串
+func firstFailed(dependsOn []string, failed map[string]bool) string {
+	for _, dep := range dependsOn {
+		if failed[dep] {
+			return dep
+		}
+	}
+	return ""
+}
+
+// reportComponentFailures 通过 Error 钩子报告组件应用失败
+func (m *Manager[T]) reportComponentFailures(failures []*ComponentError) {
+	for _, failure := range failures {
+		m.executeHook(Error, HookContext{
+			Message: fmt.Sprintf("[config] %v", failure),
+			Event:   EventComponentFailed,
+			Err:     failure,
+			Attrs:   []slog.Attr{slog.String("component", failure.Name)},
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package configx
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type componentTestConfig struct {
+	Database struct {
+		DSN string `mapstructure:"dsn"`
+	} `mapstructure:"database"`
+	Server struct {
+		Port int `mapstructure:"port"`
+	} `mapstructure:"server"`
+}
+
+// keyedComponent 声明依赖字段的测试组件
+type keyedComponent struct {
+	keys  []string
+	err   error
+	calls *[]string
+	name  string
+}
+
+func (c keyedComponent) Apply(oldCfg, newCfg componentTestConfig) error {
+	*c.calls = append(*c.calls, c.name)
+	return c.err
+}
+
+func (c keyedComponent) Keys() []string {
+	return c.keys
+}
+
+// TestApplyComponentsByKeys 测试按变化字段选择组件并按依赖顺序应用
+func TestApplyComponentsByKeys(t *testing.T) {
+	manager := NewManager(componentTestConfig{})
+	var calls []string
+
+	manager.Register("http", keyedComponent{name: "http", keys: []string{"server"}, calls: &calls}, "db")
+	manager.Register("db", keyedComponent{name: "db", keys: []string{"database.dsn"}, calls: &calls})
+	manager.Register("cache", keyedComponent{name: "cache", keys: []string{"cache"}, calls: &calls})
+	manager.Register("metrics", ReloadableFunc[componentTestConfig](func(oldCfg, newCfg componentTestConfig) error {
+		calls = append(calls, "metrics")
+		return nil
+	}))
+
+	applied, failures := manager.applyComponents(componentTestConfig{}, componentTestConfig{}, []string{"database.dsn", "server.port"})
+	if len(failures) != 0 {
+		t.Fatalf("不应有失败的组件: %v", failures)
+	}
+	if got := strings.Join(calls, ","); got != "db,http,metrics" {
+		t.Errorf("应用顺序错误: %s", got)
+	}
+	if got := strings.Join(applied, ","); got != "db,http,metrics" {
+		t.Errorf("已应用组件错误: %s", got)
+	}
+
+	calls = nil
+	manager.applyComponents(componentTestConfig{}, componentTestConfig{}, nil)
+	if len(calls) != 0 {
+		t.Errorf("配置未变化时不应应用组件: %v", calls)
+	}
+}
+
+// TestApplyComponentsFailures 测试组件失败、依赖跳过与循环依赖
+func TestApplyComponentsFailures(t *testing.T) {
+	manager := NewManager(componentTestConfig{})
+	var calls []string
+	boom := errors.New("boom")
+
+	manager.Register("db", keyedComponent{name: "db", keys: []string{"database"}, err: boom, calls: &calls})
+	manager.Register("http", keyedComponent{name: "http", keys: []string{"server"}, calls: &calls}, "db")
+	manager.Register("a", keyedComponent{name: "a", calls: &calls}, "b")
+	manager.Register("b", keyedComponent{name: "b", calls: &calls}, "a")
+
+	applied, failures := manager.applyComponents(componentTestConfig{}, componentTestConfig{}, []string{"database.dsn", "server.port"})
+	if len(applied) != 0 {
+		t.Errorf("不应有成功的组件: %v", applied)
+	}
+
+	byName := make(map[string]*ComponentError)
+	for _, failure := range failures {
+		byName[failure.Name] = failure
+	}
+	if !errors.Is(byName["db"], boom) {
+		t.Errorf("db 应报告原始错误: %v", byName["db"])
+	}
+	if byName["http"] == nil || !strings.Contains(byName["http"].Error(), "db") {
+		t.Errorf("http 应因依赖失败被跳过: %v", byName["http"])
+	}
+	if byName["a"] == nil || byName["b"] == nil {
+		t.Errorf("循环依赖的组件应报告失败: %v", failures)
+	}
+	if got := strings.Join(calls, ","); got != "db" {
+		t.Errorf("只应调用 db: %s", got)
+	}
+}
+
+// TestApplyComponentsPanic 测试组件 panic 被恢复并按组件报告
+func TestApplyComponentsPanic(t *testing.T) {
+	manager := NewManager(componentTestConfig{})
+	var reported []string
+	manager.AddHook(Error, func(ctx HookContext) {
+		for _, attr := range ctx.Attrs {
+			if ctx.Event == EventComponentFailed && attr.Key == "component" {
+				reported = append(reported, attr.Value.String())
+			}
+		}
+	})
+	manager.Register("db", ReloadableFunc[componentTestConfig](func(oldCfg, newCfg componentTestConfig) error {
+		panic("boom")
+	}))
+	manager.Register("http", ReloadableFunc[componentTestConfig](func(oldCfg, newCfg componentTestConfig) error {
+		return nil
+	}))
+
+	applied, failures := manager.applyComponents(componentTestConfig{}, componentTestConfig{}, []string{"server.port"})
+	manager.reportComponentFailures(failures)
+	if len(failures) != 1 || failures[0].Name != "db" || !strings.Contains(failures[0].Error(), "boom") {
+		t.Errorf("panic 应作为 db 的 ComponentError 报告: %v", failures)
+	}
+	if !slices.Equal(applied, []string{"http"}) {
+		t.Errorf("其他组件应继续应用: %v", applied)
+	}
+	if !slices.Equal(reported, []string{"db"}) {
+		t.Errorf("component_failed 事件应在 Attrs 中带组件名称: %v", reported)
+	}
+}
+
+// TestComponentsOnReload 测试热重载后应用组件
+func TestComponentsOnReload(t *testing.T) {
+	manager := newTestManager[componentTestConfig](t, "database:\n  dsn: old\nserver:\n  port: 80\n", nil)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	var got componentTestConfig
+	manager.Register("db", ReloadableFunc[componentTestConfig](func(oldCfg, newCfg componentTestConfig) error {
+		got = newCfg
+		return nil
+	}))
+
+	if err := reloadTestFile(t, manager, "database:\n  dsn: new\nserver:\n  port: 80\n"); err != nil {
+		t.Fatal(err)
+	}
+	if got.Database.DSN != "new" {
+		t.Errorf("组件未收到新配置: %+v", got)
+	}
+}
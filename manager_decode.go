@@ -0,0 +1,104 @@
+package configx
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// options 线程安全地获取配置选项
+// 如果选项尚未初始化，则使用默认选项
+func (m *Manager[T]) options() *Option {
+	m.SetOption(nil)
+
+	m.optsMutex.Lock()
+	defer m.optsMutex.Unlock()
+	return m.opts
+}
+
+// configMeta 构建配置时收集的字段信息，配置被替换为当前配置时才通过 storeMeta 记录
+type configMeta struct {
+	appliedDefaults []string          // 由 default 标签填充的字段
+	templateKeys    []string          // 原始值包含插值表达式的字段
+	encryptedKeys   map[string]string // 加密字段路径到原始密文的映射
+}
+
+// storeMeta 记录当前配置对应的字段信息
+func (m *Manager[T]) storeMeta(meta configMeta) {
+	m.recordAppliedDefaults(meta.appliedDefaults)
+	m.templateKeys.Store(&meta.templateKeys)
+	m.encryptedKeys.Store(&meta.encryptedKeys)
+}
+
+// buildConfig 根据 Viper 中已读取的配置构建新的配置对象
+// 处理流程：版本迁移 → 弃用字段迁移 → 标签默认值 → 解密 → 变量插值 → 严格模式检查 → 解码
+// 返回值：
+//
+//	*T: 解析后的配置对象
+//	configMeta: 构建过程中收集的字段信息（调用方在替换配置后调用 storeMeta 记录）
+//	[]HookContext: 构建过程中产生的待触发钩子（由调用方在锁外触发）
+//	error: 校验或解析失败时返回错误
+func (m *Manager[T]) buildConfig() (*T, configMeta, []HookContext, error) {
+	settings := m.vp.AllSettings()
+	warnings, err := m.applyMigrations(settings)
+	if err != nil {
+		return nil, configMeta{}, warnings, err
+	}
+	warnings = append(warnings, m.applyAliases(settings)...)
+
+	var applied []string
+	configType := reflect.TypeOf((*T)(nil)).Elem()
+	applyTagDefaults(settings, configType, "", &applied)
+
+	opts := m.options()
+	encrypted, err := decryptSettings(settings, opts.KeyProvider)
+	if err != nil {
+		return nil, configMeta{}, warnings, err
+	}
+
+	var templated []string
+	if opts.Interpolate {
+		if templated, err = interpolateSettings(settings); err != nil {
+			return nil, configMeta{}, warnings, err
+		}
+	}
+
+	if opts.Strict {
+		allow := opts.StrictAllowKeys
+		if migrations, _ := m.migrationList(); len(migrations) > 0 {
+			// 启用版本迁移时，顶层 version 字段总是合法的
+			allow = append([]string{VersionKey}, allow...)
+		}
+		if err := m.checkUnknownKeys(settings, allow); err != nil {
+			return nil, configMeta{}, warnings, err
+		}
+		settings = pruneUnknownKeys(settings, configType).(map[string]any)
+	}
+
+	var newConfig T
+	if err := m.decode(settings, &newConfig, opts.Strict); err != nil {
+		return nil, configMeta{}, warnings, err
+	}
+	meta := configMeta{appliedDefaults: applied, templateKeys: templated, encryptedKeys: encrypted}
+	return &newConfig, meta, warnings, nil
+}
+
+// decode 将配置映射解析到泛型结构体
+// 解码行为与 viper.Unmarshal 保持一致（弱类型转换、Duration 与切片转换），
+// 并额外支持 decodeHook 中列出的类型与自定义解码钩子
+// 严格模式下启用 mapstructure 的 ErrorUnused，作为未知字段检查的兜底
+// 错误信息中的敏感字段（Secret 类型或 secret:"true" 标签）原始值会被脱敏
+func (m *Manager[T]) decode(settings map[string]any, out *T, strict bool) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           out,
+		WeaklyTypedInput: true,
+		ErrorUnused:      strict,
+		DecodeHook:       m.decodeHook(),
+	})
+	if err != nil {
+		return fmt.Errorf("创建解码器失败: %w", err)
+	}
+	// 解码错误中可能包含敏感字段的原始值
+	return redactSettingsError(decoder.Decode(settings), settings, reflect.TypeOf(out).Elem())
+}
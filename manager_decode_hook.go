@@ -0,0 +1,180 @@
+package configx
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// DecodeHook 自定义解码钩子
+// 在配置值解码到字段之前调用，可以将 data 转换为目标类型 to 的值
+// 参数：
+//
+//	from: 原始值类型
+//	to: 目标字段类型
+//	data: 原始值
+//
+// 返回值：
+//
+//	any: 转换后的值（不处理时原样返回 data）
+//	error: 转换失败时返回错误
+type DecodeHook func(from, to reflect.Type, data any) (any, error)
+
+// RegisterDecodeHook 注册自定义解码钩子
+// 自定义钩子按注册顺序在内置钩子之前执行
+// 返回值：
+//
+//	*Manager[T]: 返回管理器实例以支持链式调用
+//
+// 示例：
+//
+//	manager.RegisterDecodeHook(func(from, to reflect.Type, data any) (any, error) {
+//	    if from.Kind() != reflect.String || to != reflect.TypeOf(Level(0)) {
+//	        return data, nil
+//	    }
+//	    return ParseLevel(data.(string))
+//	})
+func (m *Manager[T]) RegisterDecodeHook(hooks ...DecodeHook) *Manager[T] {
+	m.decodeMutex.Lock()
+	defer m.decodeMutex.Unlock()
+	m.decodeHooks = append(m.decodeHooks, hooks...)
+	return m
+}
+
+// decodeHook 组合自定义钩子与内置钩子
+// 内置支持：time.Duration、*time.Location、net.IP、*net.IPNet、netip.Addr、netip.Prefix、
+// *url.URL、*regexp.Regexp、ByteSize 以及任何实现 encoding.TextUnmarshaler 的类型
+func (m *Manager[T]) decodeHook() mapstructure.DecodeHookFunc {
+	m.decodeMutex.RLock()
+	hooks := make([]mapstructure.DecodeHookFunc, 0, len(m.decodeHooks)+10)
+	for _, h := range m.decodeHooks {
+		hooks = append(hooks, mapstructure.DecodeHookFuncType(h))
+	}
+	m.decodeMutex.RUnlock()
+
+	hooks = append(hooks,
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToTimeLocationHookFunc(),
+		mapstructure.StringToIPHookFunc(),
+		mapstructure.StringToIPNetHookFunc(),
+		mapstructure.StringToNetIPAddrHookFunc(),
+		mapstructure.StringToNetIPPrefixHookFunc(),
+		mapstructure.StringToURLHookFunc(),
+		stringToRegexpHook,
+		textUnmarshalerHook,
+		mapstructure.StringToSliceHookFunc(","),
+	)
+	return mapstructure.ComposeDecodeHookFunc(hooks...)
+}
+
+// stringToRegexpHook 将字符串编译为 *regexp.Regexp
+func stringToRegexpHook(from, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String || to != reflect.TypeOf((*regexp.Regexp)(nil)) {
+		return data, nil
+	}
+	return regexp.Compile(data.(string))
+}
+
+// textUnmarshalerHook 对实现 encoding.TextUnmarshaler 的类型调用 UnmarshalText
+func textUnmarshalerHook(from, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String {
+		return data, nil
+	}
+	ptr := reflect.New(to)
+	unmarshaler, ok := ptr.Interface().(encoding.TextUnmarshaler)
+	if !ok {
+		return data, nil
+	}
+	if err := unmarshaler.UnmarshalText([]byte(reflect.ValueOf(data).String())); err != nil {
+		return nil, err
+	}
+	return ptr.Elem().Interface(), nil
+}
+
+// formatValue 将字段值格式化为可写回配置文件的可读形式
+// 优先使用 encoding.TextMarshaler，其次是 fmt.Stringer（如 time.Duration 输出 "30s"）
+// Secret 写回真实值（写回文件不属于展示场景）
+func formatValue(v reflect.Value) string {
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return ""
+	}
+	if v.Type() == secretType {
+		return v.String()
+	}
+	if v.CanInterface() {
+		switch val := v.Interface().(type) {
+		case encoding.TextMarshaler:
+			if text, err := val.MarshalText(); err == nil {
+				return string(text)
+			}
+		case fmt.Stringer:
+			return val.String()
+		}
+	}
+	if v.Kind() == reflect.Pointer {
+		return formatValue(v.Elem())
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// formatScalar 返回按文本写回 YAML 时使用的标量
+// 布尔值与数字按原样写出，保留字段类型；字符串以及 Duration 等使用可读形式的值加引号
+func formatScalar(v reflect.Value) string {
+	text := formatValue(v)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	var plain string
+	switch v.Kind() {
+	case reflect.Bool:
+		plain = strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		plain = strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		plain = strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		plain = strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	}
+	if plain != "" && text == plain {
+		return text
+	}
+	return `"` + text + `"`
+}
+
+// isLeafValue 判断字段是否应作为单个值处理（而不是递归其内部字段）
+// 实现 TextMarshaler 的结构体（如 time.Time、netip.Prefix）以及 url.URL、time.Location 视为单个值；
+// 只实现 fmt.Stringer 的配置结构体仍按字段处理，避免写回 String() 的输出
+func isLeafValue(v reflect.Value) bool {
+	t := v.Type()
+	if indirectType(t).Kind() != reflect.Struct {
+		return true
+	}
+	return isTextType(t)
+}
+
+// textTypes 没有实现 TextMarshaler、但按单个文本值解码的结构体类型
+var textTypes = map[reflect.Type]bool{
+	reflect.TypeOf(url.URL{}):       true,
+	reflect.TypeOf(time.Location{}): true,
+}
+
+// isTextType 判断类型是否作为单个文本值处理：实现 TextMarshaler，或为 textTypes 中的类型
+func isTextType(t reflect.Type) bool {
+	base := indirectType(t)
+	if textTypes[base] {
+		return true
+	}
+	textMarshaler := reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	for _, candidate := range []reflect.Type{t, base, reflect.PointerTo(base)} {
+		if candidate.Implements(textMarshaler) {
+			return true
+		}
+	}
+	return false
+}
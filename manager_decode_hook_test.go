@@ -0,0 +1,145 @@
+package configx
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+type level int
+
+type richTypeTestConfig struct {
+	Timeout  time.Duration  `mapstructure:"timeout"`
+	Zone     *time.Location `mapstructure:"zone"`
+	IP       net.IP         `mapstructure:"ip"`
+	Subnet   netip.Prefix   `mapstructure:"subnet"`
+	Endpoint *url.URL       `mapstructure:"endpoint"`
+	Pattern  *regexp.Regexp `mapstructure:"pattern"`
+	MaxBody  ByteSize       `mapstructure:"max_body"`
+	Started  time.Time      `mapstructure:"started"`
+	Level    level          `mapstructure:"level"`
+}
+
+// TestRichTypeDecoding 测试内置解码钩子与自定义解码钩子
+func TestRichTypeDecoding(t *testing.T) {
+	content := strings.Join([]string{
+		"timeout: 30s",
+		"zone: Asia/Shanghai",
+		"ip: 10.0.0.1",
+		"subnet: 10.0.0.0/8",
+		"endpoint: https://example.com/api",
+		"pattern: ^user-[0-9]+$",
+		"max_body: 512MiB",
+		"started: 2024-01-02T03:04:05Z",
+		"level: debug",
+	}, "\n")
+	manager := newTestManager[richTypeTestConfig](t, content, nil)
+	manager.RegisterDecodeHook(func(from, to reflect.Type, data any) (any, error) {
+		if from.Kind() != reflect.String || to != reflect.TypeOf(level(0)) {
+			return data, nil
+		}
+		if data.(string) == "debug" {
+			return level(-1), nil
+		}
+		return level(0), nil
+	})
+
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	cfg := *manager.config
+
+	if cfg.Timeout != 30*time.Second || cfg.Zone.String() != "Asia/Shanghai" || !cfg.IP.Equal(net.ParseIP("10.0.0.1")) {
+		t.Errorf("Duration/Location/IP 解析错误: %+v", cfg)
+	}
+	if cfg.Subnet.String() != "10.0.0.0/8" || cfg.Endpoint.Host != "example.com" || !cfg.Pattern.MatchString("user-42") {
+		t.Errorf("Prefix/URL/Regexp 解析错误: %+v", cfg)
+	}
+	if cfg.MaxBody != 512*MiB || cfg.Started.Year() != 2024 || cfg.Level != -1 {
+		t.Errorf("ByteSize/TextUnmarshaler/自定义钩子解析错误: %+v", cfg)
+	}
+}
+
+// TestUpdateFieldWritesReadableForm 测试 UpdateField 写回可读形式
+func TestUpdateFieldWritesReadableForm(t *testing.T) {
+	manager := newTestManager[richTypeTestConfig](t, "timeout: 30s\nmax_body: 1MiB\n", nil)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+
+	err := manager.UpdateField(func(c *richTypeTestConfig) {
+		c.Timeout = 90 * time.Second
+		c.MaxBody = 2 * GiB
+	})
+	if err != nil {
+		t.Fatalf("更新配置失败: %v", err)
+	}
+
+	data, _ := os.ReadFile(manager.vp.ConfigFileUsed())
+	if !strings.Contains(string(data), `timeout: "1m30s"`) || !strings.Contains(string(data), `max_body: "2GiB"`) {
+		t.Errorf("写回内容错误:\n%s", data)
+	}
+}
+
+type stringerSection struct {
+	Host string `mapstructure:"host"`
+	Port int    `mapstructure:"port"`
+}
+
+// String 配置结构体常见的展示方法，不应使其被当作单个值写回
+func (s stringerSection) String() string {
+	return fmt.Sprintf("%s:%d", s.Host, s.Port)
+}
+
+type stringerTestConfig struct {
+	DB stringerSection `mapstructure:"db"`
+}
+
+// TestUpdateFieldStringerSection 测试实现 Stringer 的配置结构体仍按字段写回
+func TestUpdateFieldStringerSection(t *testing.T) {
+	manager := newTestManager[stringerTestConfig](t, "db:\n  host: localhost\n  port: 1\n", nil)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	if err := manager.UpdateField(func(c *stringerTestConfig) { c.DB.Port = 2 }); err != nil {
+		t.Fatalf("更新配置失败: %v", err)
+	}
+
+	data, _ := os.ReadFile(manager.vp.ConfigFileUsed())
+	if !strings.Contains(string(data), "port: 2\n") || !strings.Contains(string(data), "host: localhost") {
+		t.Errorf("写回内容错误:\n%s", data)
+	}
+	if keys := changedKeys(stringerTestConfig{}, stringerTestConfig{DB: stringerSection{Port: 1}}); !reflect.DeepEqual(keys, []string{"db.port"}) {
+		t.Errorf("ChangedKeys 应包含子字段: %v", keys)
+	}
+}
+
+// TestParseByteSize 测试字节大小解析与格式化
+func TestParseByteSize(t *testing.T) {
+	cases := map[string]ByteSize{
+		"1024":   1024,
+		"512MiB": 512 * MiB,
+		"1.5GB":  1500 * MB,
+		"64k":    64 * KiB,
+		"10 KB":  10 * KB,
+	}
+	for in, want := range cases {
+		got, err := ParseByteSize(in)
+		if err != nil || got != want {
+			t.Errorf("ParseByteSize(%q) = %v, %v; 期望 %v", in, got, err, want)
+		}
+	}
+	if _, err := ParseByteSize("12XB"); err == nil {
+		t.Error("无效单位应返回错误")
+	}
+	if s := (1536 * KiB).String(); s != "1536KiB" {
+		t.Errorf("String() = %q", s)
+	}
+}
@@ -0,0 +1,121 @@
+package configx
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// DefaultTag 声明字段默认值的结构体标签名
+//
+// 示例：
+//
+//	type ServerConfig struct {
+//	    Port        int           `mapstructure:"port" default:"8080"`
+//	    ReadTimeout time.Duration `mapstructure:"read_timeout" default:"30s"`
+//	    Tags        []string      `mapstructure:"tags" default:"a,b"`
+//	}
+const DefaultTag = "default"
+
+// applyTagDefaults 将 default 标签的值填充到配置映射中缺失的字段
+// 支持嵌套结构体、结构体切片/映射的每个元素以及指针字段
+// 参数：
+//
+//	settings: 配置映射（会被直接修改）
+//	t: 与 settings 对应的结构体类型
+//	prefix: 字段路径前缀
+//	applied: 记录被填充默认值的字段路径
+func applyTagDefaults(settings map[string]any, t reflect.Type, prefix string, applied *[]string) {
+	t = indirectType(t)
+	if t.Kind() != reflect.Struct {
+		return
+	}
+
+	for name, field := range structKeyFields(t) {
+		if name == remainFieldKey {
+			continue
+		}
+		p := joinKeyPath(prefix, name)
+		key, exists := findSettingKey(settings, name)
+
+		if !exists {
+			if tag, ok := field.Tag.Lookup(DefaultTag); ok {
+				settings[name] = tag
+				*applied = append(*applied, p)
+				continue
+			}
+		}
+
+		ft := indirectType(field.Type)
+		switch ft.Kind() {
+		case reflect.Struct:
+			if exists {
+				if sub, ok := settings[key].(map[string]any); ok {
+					applyTagDefaults(sub, ft, p, applied)
+				}
+				continue
+			}
+			// 字段缺失时，仅在嵌套结构体存在默认值时才创建该层级
+			sub := make(map[string]any)
+			applyTagDefaults(sub, ft, p, applied)
+			if len(sub) > 0 {
+				settings[name] = sub
+			}
+		case reflect.Slice, reflect.Array:
+			items, ok := settings[key].([]any)
+			if !exists || !ok {
+				continue
+			}
+			for i, item := range items {
+				if sub, ok := item.(map[string]any); ok {
+					applyTagDefaults(sub, ft.Elem(), p+"["+strconv.Itoa(i)+"]", applied)
+				}
+			}
+		case reflect.Map:
+			values, ok := settings[key].(map[string]any)
+			if !exists || !ok {
+				continue
+			}
+			for k, item := range values {
+				if sub, ok := item.(map[string]any); ok {
+					applyTagDefaults(sub, ft.Elem(), joinKeyPath(p, k), applied)
+				}
+			}
+		}
+	}
+}
+
+// Defaults 获取仅由 default 标签构成的配置
+// 返回值：
+//
+//	T: 按 default 标签解析得到的配置（未声明默认值的字段为零值）
+//	error: 默认值无法转换为字段类型时返回错误
+func (m *Manager[T]) Defaults() (T, error) {
+	settings := make(map[string]any)
+	var applied []string
+	applyTagDefaults(settings, reflect.TypeOf((*T)(nil)).Elem(), "", &applied)
+
+	var defaults T
+	if err := m.decode(settings, &defaults, false); err != nil {
+		return defaults, err
+	}
+	return defaults, nil
+}
+// AppliedDefaults 获取当前生效的配置中由 default 标签填充的字段路径（被否决或失败的重载不会改变结果）
+// AppliedDefaults 获取最近一次加载时由 default 标签填充的字段路径
+// 返回值：
+//
+//	[]string: 按字母排序的字段路径，如 ["server.port", "servers[0].timeout"]
+func (m *Manager[T]) AppliedDefaults() []string {
+	applied := m.appliedDefaults.Load()
+	if applied == nil {
+		return nil
+	}
+	return append([]string(nil), (*applied)...)
+}
+
+// recordAppliedDefaults 记录当前配置中填充的默认值字段
+func (m *Manager[T]) recordAppliedDefaults(applied []string) {
+	sort.Strings(applied)
+	m.appliedDefaults.Store(&applied)
+}
@@ -0,0 +1,87 @@
+package configx
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type defaultTagTestConfig struct {
+	Server struct {
+		Port        int           `mapstructure:"port" default:"8080"`
+		ReadTimeout time.Duration `mapstructure:"read_timeout" default:"30s"`
+	} `mapstructure:"server"`
+	Upstreams []struct {
+		Host   string `mapstructure:"host"`
+		Weight int    `mapstructure:"weight" default:"1"`
+	} `mapstructure:"upstreams"`
+	Cache *struct {
+		Size int `mapstructure:"size" default:"128"`
+	} `mapstructure:"cache"`
+	Tags []string `mapstructure:"tags" default:"a,b"`
+}
+
+// TestTagDefaultsFillMissingKeys 测试 default 标签填充缺失字段
+func TestTagDefaultsFillMissingKeys(t *testing.T) {
+	manager := newTestManager[defaultTagTestConfig](t, "server:\n  port: 9000\nupstreams:\n  - host: a\n  - host: b\n    weight: 5\n", nil)
+
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	cfg, _ := manager.GetConfig()
+
+	if cfg.Server.Port != 9000 {
+		t.Errorf("文件中的值不应被默认值覆盖: %d", cfg.Server.Port)
+	}
+	if cfg.Server.ReadTimeout != 30*time.Second {
+		t.Errorf("Duration 默认值错误: %v", cfg.Server.ReadTimeout)
+	}
+	if cfg.Upstreams[0].Weight != 1 || cfg.Upstreams[1].Weight != 5 {
+		t.Errorf("切片元素默认值错误: %+v", cfg.Upstreams)
+	}
+	if cfg.Cache == nil || cfg.Cache.Size != 128 {
+		t.Errorf("指针字段默认值错误: %+v", cfg.Cache)
+	}
+	if !reflect.DeepEqual(cfg.Tags, []string{"a", "b"}) {
+		t.Errorf("切片默认值错误: %v", cfg.Tags)
+	}
+
+	want := []string{"cache.size", "server.read_timeout", "tags", "upstreams[0].weight"}
+	if got := manager.AppliedDefaults(); !reflect.DeepEqual(got, want) {
+		t.Errorf("AppliedDefaults 错误: %v", got)
+	}
+}
+
+// TestAppliedDefaultsKeptOnRejectedReload 测试重载被否决时保留当前配置的默认值记录
+func TestAppliedDefaultsKeptOnRejectedReload(t *testing.T) {
+	manager := newTestManager[defaultTagTestConfig](t, "server:\n  port: 9000\n", nil)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	want := manager.AppliedDefaults()
+
+	manager.OnReload(ReloadFuncs[defaultTagTestConfig]{
+		PrepareFunc: func(oldConfig, newConfig defaultTagTestConfig) error { return errors.New("veto") },
+	})
+	content := "server:\n  port: 9001\n  read_timeout: 1s\ncache:\n  size: 1\ntags: [x]\n"
+	if err := reloadTestFile(t, manager, content); err == nil {
+		t.Fatal("期望重载被否决")
+	}
+	if got := manager.AppliedDefaults(); !reflect.DeepEqual(got, want) {
+		t.Errorf("重载被否决后 AppliedDefaults 不应变化: %v，期望 %v", got, want)
+	}
+}
+
+// TestDefaults 测试获取仅由标签构成的默认配置
+func TestDefaults(t *testing.T) {
+	manager := NewManager(defaultTagTestConfig{})
+
+	defaults, err := manager.Defaults()
+	if err != nil {
+		t.Fatalf("获取默认配置失败: %v", err)
+	}
+	if defaults.Server.Port != 8080 || defaults.Server.ReadTimeout != 30*time.Second || defaults.Cache.Size != 128 {
+		t.Errorf("默认配置错误: %+v", defaults)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 
 	"gopkg.in/yaml.v3"
 )
@@ -22,21 +23,24 @@ func (m *Manager[T]) ensureConfigFile(opts *Option) error {
 		// 文件不存在，写入默认配置
 		// Use the defaultConfig from Manager if available
 		if m.defaultConfig != nil {
-			data, err := yaml.Marshal(m.defaultConfig)
+			// 按 mapstructure 字段名生成，Secret 与 secret:"true" 字段写入真实的默认值而不是脱敏值
+			data, err := yaml.Marshal(plainValue(reflect.ValueOf(m.defaultConfig)))
 			if err != nil {
 				return fmt.Errorf("failed to marshal default config: %w", err)
 			}
 
-			if err := os.WriteFile(cfgFile, data, 0644); err != nil {
+			if err := os.WriteFile(cfgFile, data, m.configFileMode(opts)); err != nil {
 				return fmt.Errorf("failed to write default config file: %w", err)
 			}
 
 			m.executeHook(Info, HookContext{
 				Message: fmt.Sprintf("[config] 默认配置文件已生成: %s", cfgFile),
+				Event:   EventFileCreated,
+				File:    cfgFile,
 			})
 		} else {
 			// If no default config provided, create empty file
-			if err := os.WriteFile(cfgFile, []byte{}, 0644); err != nil {
+			if err := os.WriteFile(cfgFile, []byte{}, m.configFileMode(opts)); err != nil {
 				return fmt.Errorf("failed to create config file: %w", err)
 			}
 		}
@@ -0,0 +1,220 @@
+package configx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// HandlerOption 配置变更回调的执行选项
+type HandlerOption func(*handlerConfig)
+
+// handlerConfig 回调执行策略
+type handlerConfig struct {
+	async    bool
+	timeout  time.Duration
+	priority int
+}
+
+// Async 异步执行回调，不阻塞文件监听与其他回调
+// 同一回调的多次调用仍按事件顺序依次执行
+func Async() HandlerOption {
+	return func(c *handlerConfig) { c.async = true }
+}
+
+// WithTimeout 设置回调超时时间
+// 超时后回调的 ctx 被取消并触发 Error 钩子；同步回调不再继续等待，
+// 但该回调的后续事件会在本次调用返回后才开始执行
+func WithTimeout(d time.Duration) HandlerOption {
+	return func(c *handlerConfig) { c.timeout = d }
+}
+
+// WithPriority 设置回调优先级，数值越大越先执行，相同优先级按注册顺序执行
+func WithPriority(priority int) HandlerOption {
+	return func(c *handlerConfig) { c.priority = priority }
+}
+
+// changeHandler 已注册的配置变更回调
+// 每个回调拥有独立的执行队列，保证其看到的事件顺序与发生顺序一致
+type changeHandler struct {
+	id      uint64
+	handle  HandlerFunc
+	config  handlerConfig
+	mu      sync.Mutex
+	pending []*handlerJob
+	notify  chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+// stop 停止回调的执行队列，可以重复调用
+func (h *changeHandler) stop() {
+	h.once.Do(func() { close(h.done) })
+}
+
+// handlerJob 一次回调调用
+type handlerJob struct {
+	ctx      *Context
+	cancel   context.CancelFunc
+	finished chan struct{}
+}
+
+// OnChange 注册配置变更回调
+// Init 传入的回调等价于使用默认选项（同步、无超时、优先级 0）注册；
+// 管理器已关闭时不注册回调，返回的句柄调用 Remove 无任何效果
+// 参数：
+//
+//	handle: 回调函数
+//	opts: 执行选项（Async、WithTimeout、WithPriority）
+//
+// 返回值：
+//
+//	*HookHandle: 注册句柄，调用 Remove() 注销回调
+//
+// 示例：
+//
+//	manager.OnChange(func(ctx *configx.Context) {
+//	    refreshCache(ctx)
+//	}, configx.Async(), configx.WithTimeout(5*time.Second), configx.WithPriority(10))
+func (m *Manager[T]) OnChange(handle HandlerFunc, opts ...HandlerOption) *HookHandle {
+	h := &changeHandler{
+		handle: handle,
+		notify: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(&h.config)
+	}
+
+	m.handlerMutex.Lock()
+	if m.isClosed() {
+		// 管理器已关闭，不再启动执行队列
+		m.handlerMutex.Unlock()
+		return &HookHandle{remove: func() {}}
+	}
+	m.handlerNextID++
+	h.id = m.handlerNextID
+	m.changeHandlers = append(m.changeHandlers, h)
+	// 稳定排序：优先级高的先执行，相同优先级保持注册顺序
+	sort.SliceStable(m.changeHandlers, func(i, j int) bool {
+		return m.changeHandlers[i].config.priority > m.changeHandlers[j].config.priority
+	})
+	m.handlerMutex.Unlock()
+
+	go m.runChangeHandler(h)
+
+	return &HookHandle{remove: func() {
+		m.handlerMutex.Lock()
+		for i, entry := range m.changeHandlers {
+			if entry == h {
+				m.changeHandlers = append(append([]*changeHandler(nil), m.changeHandlers[:i]...), m.changeHandlers[i+1:]...)
+				break
+			}
+		}
+		m.handlerMutex.Unlock()
+		h.stop()
+	}}
+}
+
+// dispatchChange 将配置变更事件分发给全部回调
+// 同步回调按优先级依次执行并等待完成（或超时），异步回调只入队不等待
+func (m *Manager[T]) dispatchChange(newContext func() *Context) {
+	m.handlerMutex.RLock()
+	handlers := append([]*changeHandler(nil), m.changeHandlers...)
+	m.handlerMutex.RUnlock()
+
+	for _, h := range handlers {
+		base := context.Background()
+		var cancel context.CancelFunc
+		if h.config.timeout > 0 {
+			base, cancel = context.WithTimeout(base, h.config.timeout)
+		} else {
+			base, cancel = context.WithCancel(base)
+		}
+		ctx := newContext()
+		ctx.Context = base
+
+		job := &handlerJob{ctx: ctx, cancel: cancel, finished: make(chan struct{})}
+		h.mu.Lock()
+		h.pending = append(h.pending, job)
+		h.mu.Unlock()
+		select {
+		case h.notify <- struct{}{}:
+		default:
+		}
+
+		if h.config.async {
+			continue
+		}
+		select {
+		case <-job.finished:
+		case <-base.Done():
+		case <-h.done:
+		}
+	}
+}
+
+// runChangeHandler 回调的执行队列，按入队顺序逐个执行
+func (m *Manager[T]) runChangeHandler(h *changeHandler) {
+	for {
+		select {
+		case <-h.done:
+			h.mu.Lock()
+			for _, job := range h.pending {
+				job.cancel()
+			}
+			h.pending = nil
+			h.mu.Unlock()
+			return
+		case <-h.notify:
+		}
+
+		for {
+			h.mu.Lock()
+			if len(h.pending) == 0 {
+				h.mu.Unlock()
+				break
+			}
+			job := h.pending[0]
+			h.pending = h.pending[1:]
+			h.mu.Unlock()
+
+			m.runChangeJob(h, job)
+		}
+	}
+}
+
+// runChangeJob 执行一次回调，恢复 panic 并报告超时
+func (m *Manager[T]) runChangeJob(h *changeHandler, job *handlerJob) {
+	stop := context.AfterFunc(job.ctx.Context, func() {
+		if errors.Is(job.ctx.Context.Err(), context.DeadlineExceeded) {
+			m.executeHook(Error, HookContext{
+				Message:  fmt.Sprintf("[config] 配置变更回调执行超时（%v）", h.config.timeout),
+				Event:    EventHandlerTimeout,
+				File:     job.ctx.FSEvent.Name,
+				Duration: h.config.timeout,
+				Err:      job.ctx.Context.Err(),
+			})
+		}
+	})
+	defer func() {
+		if r := recover(); r != nil {
+			err := fmt.Errorf("配置变更回调 panic: %v", r)
+			m.executeHook(Error, HookContext{
+				Message: fmt.Sprintf("[config] %v", err),
+				Event:   EventHookPanic,
+				File:    job.ctx.FSEvent.Name,
+				Err:     err,
+				Attrs:   []slog.Attr{slog.Uint64("sequence", job.ctx.Sequence)},
+			})
+		}
+		stop()
+		job.cancel()
+		close(job.finished)
+	}()
+	h.handle(job.ctx)
+}
@@ -0,0 +1,108 @@
+package configx
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testChangeContext 创建测试用的回调上下文
+func testChangeContext(manager *Manager[reloadTestConfig]) func() *Context {
+	seq := manager.changeSeq.Add(1)
+	return func() *Context {
+		return &Context{Sequence: seq, manager: manager}
+	}
+}
+
+// TestOnChangePriority 测试同步回调按优先级执行
+func TestOnChangePriority(t *testing.T) {
+	manager := NewManager(reloadTestConfig{})
+	var calls []string
+	manager.OnChange(func(ctx *Context) { calls = append(calls, "default") })
+	manager.OnChange(func(ctx *Context) { calls = append(calls, "high") }, WithPriority(10))
+	manager.OnChange(func(ctx *Context) { calls = append(calls, "low") }, WithPriority(-1))
+	manager.OnChange(func(ctx *Context) { calls = append(calls, "default2") })
+
+	manager.dispatchChange(testChangeContext(manager))
+	if got := strings.Join(calls, ","); got != "high,default,default2,low" {
+		t.Errorf("执行顺序错误: %s", got)
+	}
+}
+
+// TestOnChangeAsyncOrder 测试异步回调按事件顺序执行
+func TestOnChangeAsyncOrder(t *testing.T) {
+	manager := NewManager(reloadTestConfig{})
+	var mu sync.Mutex
+	var seqs []uint64
+	var wg sync.WaitGroup
+	const events = 50
+	wg.Add(events)
+
+	handle := manager.OnChange(func(ctx *Context) {
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		seqs = append(seqs, ctx.Sequence)
+		mu.Unlock()
+		wg.Done()
+	}, Async())
+	defer handle.Remove()
+
+	for i := 0; i < events; i++ {
+		manager.dispatchChange(testChangeContext(manager))
+	}
+	mu.Lock()
+	if len(seqs) == events {
+		t.Error("异步回调不应阻塞分发")
+	}
+	mu.Unlock()
+	wg.Wait()
+
+	for i := 1; i < len(seqs); i++ {
+		if seqs[i] <= seqs[i-1] {
+			t.Fatalf("事件乱序: %v", seqs)
+		}
+	}
+}
+
+// TestOnChangeTimeoutAndPanic 测试回调超时取消与 panic 恢复
+func TestOnChangeTimeoutAndPanic(t *testing.T) {
+	manager := NewManager(reloadTestConfig{})
+	var mu sync.Mutex
+	var events []EventKind
+	manager.AddHook(Error, func(ctx HookContext) {
+		mu.Lock()
+		events = append(events, ctx.Event)
+		mu.Unlock()
+	})
+
+	canceled := make(chan struct{})
+	manager.OnChange(func(ctx *Context) {
+		<-ctx.Done()
+		close(canceled)
+	}, WithTimeout(20*time.Millisecond))
+	manager.OnChange(func(ctx *Context) { panic("bad callback") })
+
+	var after bool
+	manager.OnChange(func(ctx *Context) { after = true }, WithPriority(-1))
+
+	manager.dispatchChange(testChangeContext(manager))
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("超时后回调的 ctx 应被取消")
+	}
+	if !after {
+		t.Error("panic 不应影响后续回调")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	got := map[EventKind]bool{}
+	for _, e := range events {
+		got[e] = true
+	}
+	if !got[EventHandlerTimeout] || !got[EventHookPanic] {
+		t.Errorf("期望 handler_timeout 与 hook_panic 事件，实际: %v", events)
+	}
+}
@@ -0,0 +1,321 @@
+package configx
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// VersionKey 配置文件中记录结构版本的顶层字段
+const VersionKey = "version"
+
+// MigrationFunc 配置迁移函数
+// 参数 settings 为配置文件的原始映射（键名均为小写），迁移函数直接修改该映射
+type MigrationFunc func(settings map[string]any) error
+
+// migration 已注册的版本迁移
+type migration struct {
+	from int
+	to   int
+	fn   MigrationFunc
+}
+
+// Migrate 注册配置结构版本迁移
+// 加载与热重载时，configx 会读取配置文件顶层的 version 字段（缺省为 0），
+// 依次执行 from 与当前版本匹配的迁移函数，直到没有可用的迁移为止，然后再解析到 T
+// 参数：
+//
+//	from: 迁移前的版本
+//	to: 迁移后的版本，必须大于 from
+//	fn: 迁移函数
+//
+// 返回值：
+//
+//	*Manager[T]: 返回管理器实例以支持链式调用
+//
+// 示例：
+//
+//	manager.Migrate(1, 2, func(s map[string]any) error {
+//	    db, _ := s["database"].(map[string]any)
+//	    if db != nil {
+//	        db["max_open_conns"] = db["pool_size"]
+//	        delete(db, "pool_size")
+//	    }
+//	    return nil
+//	})
+func (m *Manager[T]) Migrate(from, to int, fn MigrationFunc) *Manager[T] {
+	m.migrationMutex.Lock()
+	defer m.migrationMutex.Unlock()
+	if to <= from {
+		// 记录错误，由 Init、LoadConfig 与热重载返回
+		m.migrationErr = errors.Join(m.migrationErr, fmt.Errorf("%w: 无效的迁移版本 %d -> %d", ErrMigrationFailed, from, to))
+		return m
+	}
+	m.migrations = append(m.migrations, migration{from: from, to: to, fn: fn})
+	return m
+}
+
+// migrationList 线程安全地获取已注册的迁移（按 from 排序）
+// 返回值：
+//
+//	[]migration: 已注册的迁移
+//	error: 注册时记录的无效迁移
+func (m *Manager[T]) migrationList() ([]migration, error) {
+	m.migrationMutex.RLock()
+	defer m.migrationMutex.RUnlock()
+	list := append([]migration(nil), m.migrations...)
+	sort.SliceStable(list, func(i, j int) bool { return list[i].from < list[j].from })
+	return list, m.migrationErr
+}
+
+// runMigrations 对配置映射依次执行迁移函数
+// 返回值：
+//
+//	from: 配置文件原始版本
+//	to: 迁移后的版本（未执行任何迁移时与 from 相同）
+//	error: 版本字段无效或迁移函数失败时返回错误
+func runMigrations(settings map[string]any, migrations []migration) (int, int, error) {
+	from := 0
+	if raw, ok := settings[VersionKey]; ok {
+		v, ok := toInt(raw)
+		if !ok {
+			return 0, 0, fmt.Errorf("%w: 无效的版本号 %v", ErrMigrationFailed, raw)
+		}
+		from = v
+	}
+
+	current := from
+	for {
+		next := -1
+		for i, mig := range migrations {
+			if mig.from == current {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			break
+		}
+		mig := migrations[next]
+		if err := mig.fn(settings); err != nil {
+			return from, current, fmt.Errorf("%w: 版本 %d -> %d: %w", ErrMigrationFailed, mig.from, mig.to, err)
+		}
+		current = mig.to
+		settings[VersionKey] = current
+	}
+	return from, current, nil
+}
+
+// applyMigrations 在解析前对配置执行版本迁移
+// 开启 Option.MigrateWriteBack 时会将迁移结果写回文件，并保留 .bak 备份
+// 返回值：
+//
+//	[]HookContext: 迁移过程中产生的待触发钩子
+//	error: 迁移失败时返回错误
+func (m *Manager[T]) applyMigrations(settings map[string]any) ([]HookContext, error) {
+	migrations, err := m.migrationList()
+	if err != nil || len(migrations) == 0 {
+		return nil, err
+	}
+
+	from, to, err := runMigrations(settings, migrations)
+	if err != nil || from == to {
+		return nil, err
+	}
+
+	file := m.vp.ConfigFileUsed()
+	warnings := []HookContext{{
+		Message: fmt.Sprintf("[config] 配置文件 %s 已从版本 %d 迁移到版本 %d", file, from, to),
+		Pattern: Info,
+		Event:   EventMigrated,
+		File:    file,
+		Attrs:   []slog.Attr{slog.Int("from", from), slog.Int("to", to)},
+	}}
+
+	opts := m.options()
+	if !opts.MigrateWriteBack {
+		return warnings, nil
+	}
+	err = checkWritable(file, opts)
+	if err == nil {
+		err = writeBackMigration(file, migrations, m.configFileMode(opts))
+	}
+	if err != nil {
+		warnings = append(warnings, HookContext{
+			Message: fmt.Sprintf("[config] 写回迁移后的配置文件失败: %v", err),
+			Pattern: Warn,
+			Event:   EventWriteSkipped,
+			File:    file,
+			Err:     err,
+		})
+		return warnings, nil
+	}
+	warnings = append(warnings, HookContext{
+		Message: fmt.Sprintf("[config] 迁移后的配置已写回 %s，原文件备份为 %s.bak", file, file),
+		Pattern: Info,
+		Event:   EventFileWritten,
+		File:    file,
+	})
+	return warnings, nil
+}
+
+// writeBackMigration 对配置文件内容执行迁移并写回，原文件备份为 .bak
+// 迁移基于文件本身的内容，避免将 SetDefault 等默认值写入文件；
+// 只修改迁移前后不同的字段，保留其余内容的注释与键顺序；
+// 写回的文件与备份沿用原文件权限，无法读取原文件权限时使用 mode
+func writeBackMigration(file string, migrations []migration, mode os.FileMode) error {
+	if !isYAMLFile(file) {
+		return fmt.Errorf("仅支持写回 YAML 配置文件: %s", file)
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var raw map[string]any
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		raw = make(map[string]any)
+	}
+	before := lowercaseKeys(raw).(map[string]any)
+	settings := lowercaseKeys(raw).(map[string]any)
+	if _, _, err := runMigrations(settings, migrations); err != nil {
+		return err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return err
+	}
+	if yamlRoot(&doc) == nil {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	var removed []string
+	var updates []fieldUpdate
+	diffSettings(before, settings, "", &removed, &updates)
+	for _, path := range removed {
+		removeYAMLKey(&doc, path)
+	}
+	if err := setYAMLFields(&doc, updates); err != nil {
+		return err
+	}
+
+	// 新增的 version 字段放在文件顶部
+	if root := yamlRoot(&doc); root.Kind == yaml.MappingNode {
+		if _, ok := before[VersionKey]; !ok {
+			for i := 0; i+1 < len(root.Content); i += 2 {
+				if root.Content[i].Value == VersionKey {
+					pair := []*yaml.Node{root.Content[i], root.Content[i+1]}
+					rest := append(append([]*yaml.Node(nil), root.Content[:i]...), root.Content[i+2:]...)
+					root.Content = append(pair, rest...)
+					break
+				}
+			}
+		}
+	}
+	data, err := encodeYAML(&doc)
+	if err != nil {
+		return err
+	}
+
+	// 备份文件与原文件内容相同，沿用原文件权限
+	if info, err := os.Stat(file); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := writeFileMode(file+".bak", content, mode); err != nil {
+		return fmt.Errorf("备份配置文件失败: %w", err)
+	}
+	return writeFileMode(file, data, mode)
+}
+
+// writeFileMode 写入文件并设置权限
+// os.WriteFile 只在创建文件时使用 perm，已存在的文件（如旧的 .bak）需要显式修改权限
+func writeFileMode(file string, data []byte, mode os.FileMode) error {
+	if err := os.WriteFile(file, data, mode); err != nil {
+		return err
+	}
+	return os.Chmod(file, mode)
+}
+
+// diffSettings 比较迁移前后的配置映射
+// 参数：
+//
+//	before, after: 迁移前后的配置映射
+//	prefix: 当前映射的字段路径
+//	removed: 收集迁移后不存在的字段路径
+//	updates: 收集新增或值发生变化的字段
+func diffSettings(before, after map[string]any, prefix string, removed *[]string, updates *[]fieldUpdate) {
+	for _, key := range sortedKeys(before) {
+		if _, ok := after[key]; !ok {
+			*removed = append(*removed, joinKeyPath(prefix, key))
+		}
+	}
+	for _, key := range sortedKeys(after) {
+		path := joinKeyPath(prefix, key)
+		old, ok := before[key]
+		oldMap, oldIsMap := old.(map[string]any)
+		newMap, newIsMap := after[key].(map[string]any)
+		switch {
+		case ok && oldIsMap && newIsMap:
+			diffSettings(oldMap, newMap, path, removed, updates)
+		case !ok || !reflect.DeepEqual(old, after[key]):
+			*updates = append(*updates, fieldUpdate{path: path, value: reflect.ValueOf(after[key])})
+		}
+	}
+}
+
+// sortedKeys 返回映射的键（按字母排序）
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// lowercaseKeys 递归地将映射键名转换为小写（与 Viper 的行为保持一致）
+func lowercaseKeys(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, item := range val {
+			out[strings.ToLower(k)] = lowercaseKeys(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = lowercaseKeys(item)
+		}
+		return out
+	}
+	return v
+}
+
+// toInt 将配置中的数值转换为 int
+func toInt(v any) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case uint64:
+		return int(n), true
+	case float64:
+		return int(n), n == float64(int(n))
+	case string:
+		i, err := strconv.Atoi(strings.TrimSpace(n))
+		return i, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,161 @@
+package configx
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+type migrateTestConfig struct {
+	Database struct {
+		MaxOpenConns int `mapstructure:"max_open_conns"`
+	} `mapstructure:"database"`
+	Timeout int `mapstructure:"timeout"`
+}
+
+// renamePoolSize 版本 1 -> 2：pool_size 更名为 max_open_conns
+func renamePoolSize(s map[string]any) error {
+	db, _ := s["database"].(map[string]any)
+	if db == nil {
+		return errors.New("缺少 database 配置")
+	}
+	db["max_open_conns"] = db["pool_size"]
+	delete(db, "pool_size")
+	return nil
+}
+
+// TestMigrateChain 测试按版本链依次执行迁移
+func TestMigrateChain(t *testing.T) {
+	opts := NewOption()
+	opts.Strict = true
+	manager := newTestManager[migrateTestConfig](t, "version: 1\ndatabase:\n  pool_size: 8\n", opts)
+	manager.
+		Migrate(2, 3, func(s map[string]any) error {
+			s["timeout"] = 30
+			return nil
+		}).
+		Migrate(1, 2, renamePoolSize)
+
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	cfg, _ := manager.GetConfig()
+	if cfg.Database.MaxOpenConns != 8 || cfg.Timeout != 30 {
+		t.Errorf("迁移结果错误: %+v", cfg)
+	}
+}
+
+// TestMigrateWriteBack 测试迁移结果写回文件并保留备份
+func TestMigrateWriteBack(t *testing.T) {
+	opts := NewOption()
+	opts.MigrateWriteBack = true
+	original := "version: 1\ndatabase:\n  pool_size: 8\n"
+	manager := newTestManager[migrateTestConfig](t, original, opts)
+	manager.Migrate(1, 2, renamePoolSize)
+
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+
+	file := manager.vp.ConfigFileUsed()
+	backup, err := os.ReadFile(file + ".bak")
+	if err != nil || string(backup) != original {
+		t.Fatalf("备份文件错误: %q, %v", backup, err)
+	}
+	data, _ := os.ReadFile(file)
+	if !strings.HasPrefix(string(data), "version: 2\n") || !strings.Contains(string(data), "max_open_conns: 8") {
+		t.Errorf("写回内容错误:\n%s", data)
+	}
+}
+
+// TestMigrateFailure 测试迁移函数失败时拒绝加载
+func TestMigrateFailure(t *testing.T) {
+	manager := newTestManager[migrateTestConfig](t, "version: 1\n", nil)
+	manager.Migrate(1, 2, renamePoolSize)
+
+	if err := manager.LoadConfig(); !errors.Is(err, ErrMigrationFailed) {
+		t.Fatalf("期望 ErrMigrationFailed，实际: %v", err)
+	}
+}
+
+// TestMigrateWriteBackKeepsComments 测试迁移写回保留注释与键顺序
+func TestMigrateWriteBackKeepsComments(t *testing.T) {
+	opts := NewOption()
+	opts.MigrateWriteBack = true
+	manager := newTestManager[migrateTestConfig](t, "# 超时（秒）\ntimeout: 10\nversion: 1\ndatabase:\n  # 连接池\n  pool_size: 8\n  host: db # 主库\n", opts)
+	manager.Migrate(1, 2, renamePoolSize)
+
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	data, _ := os.ReadFile(manager.vp.ConfigFileUsed())
+	want := "# 超时（秒）\ntimeout: 10\nversion: 2\ndatabase:\n  host: db # 主库\n  max_open_conns: 8\n"
+	if string(data) != want {
+		t.Errorf("写回内容错误:\n%s", data)
+	}
+}
+
+// TestMigrateInvalidVersion 测试注册无效的迁移版本时加载返回错误
+func TestMigrateInvalidVersion(t *testing.T) {
+	manager := newTestManager[migrateTestConfig](t, "version: 1\ndatabase:\n  pool_size: 8\n", nil)
+	manager.Migrate(2, 1, renamePoolSize)
+
+	if err := manager.LoadConfig(); !errors.Is(err, ErrMigrationFailed) {
+		t.Fatalf("期望 ErrMigrationFailed，实际: %v", err)
+	}
+}
+
+// TestMigrateWriteBackSigned 测试需要签名校验的文件不写回迁移结果
+func TestMigrateWriteBackSigned(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	content := []byte("version: 1\ndatabase:\n  pool_size: 8\n")
+
+	opts := NewOption()
+	opts.MigrateWriteBack = true
+	opts.SignatureKey = pub
+	manager := newTestManager[migrateTestConfig](t, string(content), opts)
+	manager.Migrate(1, 2, renamePoolSize)
+	if err := os.WriteFile(opts.File()+SignatureSuffix, SignFile(content, priv), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var skipped HookContext
+	manager.AddHook(Warn, func(ctx HookContext) { skipped = ctx })
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	if data, _ := os.ReadFile(opts.File()); string(data) != string(content) {
+		t.Errorf("需要签名的文件不应被改写:\n%s", data)
+	}
+	if skipped.Event != EventWriteSkipped {
+		t.Errorf("应触发 write_skipped 事件: %+v", skipped)
+	}
+}
+
+// TestMigrateWriteBackKeepsMode 测试迁移写回的文件与备份沿用原文件权限
+func TestMigrateWriteBackKeepsMode(t *testing.T) {
+	opts := NewOption()
+	opts.MigrateWriteBack = true
+	manager := newTestManager[migrateTestConfig](t, "version: 1\ndatabase:\n  pool_size: 8\n", opts)
+	manager.Migrate(1, 2, renamePoolSize)
+	file := opts.File()
+	if err := os.WriteFile(file+".bak", []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	for _, name := range []string{file, file + ".bak"} {
+		info, err := os.Stat(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mode := info.Mode().Perm(); mode != 0600 {
+			t.Errorf("%s 的权限应为 0600，实际: %o", name, mode)
+		}
+	}
+}
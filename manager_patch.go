@@ -0,0 +1,615 @@
+package configx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// PatchKind 配置补丁格式
+type PatchKind string
+
+const (
+	// JSONPatch RFC 6902 JSON Patch（application/json-patch+json）
+	JSONPatch PatchKind = "json-patch"
+	// MergePatch RFC 7396 JSON Merge Patch（application/merge-patch+json）
+	MergePatch PatchKind = "merge-patch"
+)
+
+// TriggerPatch 调用 ApplyPatch
+const TriggerPatch ReloadTrigger = "patch"
+
+// PatchOperation RFC 6902 JSON Patch 中的一个操作
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	From  string `json:"from,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+// MarshalJSON 实现 json.Marshaler：add、replace、test 操作始终包含 value（即使为 null）
+func (op PatchOperation) MarshalJSON() ([]byte, error) {
+	type operation PatchOperation
+	switch op.Op {
+	case "add", "replace", "test":
+		return json.Marshal(struct {
+			Op    string `json:"op"`
+			Path  string `json:"path"`
+			Value any    `json:"value"`
+		}{op.Op, op.Path, op.Value})
+	}
+	return json.Marshal(operation(op))
+}
+
+// ApplyPatch 将补丁应用到当前配置，校验后写回配置文件
+// 补丁作用于配置的 JSON 文档形式：键为 mapstructure 名称，Duration 等值使用可读形式，Secret 为真实值；
+// 应用后的文档按严格模式解码（拒绝未知字段与类型错误），再经过 OnReload 处理器的 Prepare/Commit，
+// 通过与 UpdateField 相同的写回流程持久化，并作为一次配置变更分发给 Register 组件、OnChange 回调与 Watch 订阅者
+// 参数：
+//
+//	patch: 补丁内容
+//	kind: 补丁格式（JSONPatch 或 MergePatch）
+//
+// 返回值：
+//
+//	ChangeSummary: 变化的字段与组件应用结果（补丁没有改变配置时 ChangedKeys 为空）
+//	error: 补丁无效时返回 ErrPatchInvalid，test 操作不匹配时返回 ErrPatchTestFailed，
+//	       被否决或提交失败时返回 ErrReloadVetoed、ErrReloadRolledBack；失败时配置保持不变
+//
+// 示例：
+//
+//	// 仅当当前值仍为 10 时修改（compare-and-swap）
+//	_, err := manager.ApplyPatch([]byte(`[
+//	    {"op": "test", "path": "/database/max_open_conns", "value": 10},
+//	    {"op": "replace", "path": "/database/max_open_conns", "value": 50}
+//	]`), configx.JSONPatch)
+func (m *Manager[T]) ApplyPatch(patch []byte, kind PatchKind) (ChangeSummary, error) {
+	// 释放 reloadLock 后再分发变更
+	defer m.flushChanges()
+	m.reloadLock.Lock()
+	defer m.reloadLock.Unlock()
+
+	start := time.Now()
+	summary := ChangeSummary{Trigger: TriggerPatch, File: m.vp.ConfigFileUsed()}
+	fail := func(err error) (ChangeSummary, error) {
+		summary.Duration = time.Since(start)
+		m.executeHook(Error, HookContext{
+			Message:  fmt.Sprintf("[config] 应用配置补丁失败，保持原有配置: %v", err),
+			Event:    EventPatchFailed,
+			File:     summary.File,
+			Duration: summary.Duration,
+			Err:      err,
+			Attrs:    []slog.Attr{slog.String("kind", string(kind))},
+		})
+		return summary, err
+	}
+
+	m.rwMutex.RLock()
+	if m.config == nil {
+		m.rwMutex.RUnlock()
+		return summary, ErrConfigNotInitialized
+	}
+	oldConfig := *m.config
+	m.rwMutex.RUnlock()
+
+	newConfig, err := m.patchConfig(oldConfig, patch, kind)
+	if err != nil {
+		return fail(err)
+	}
+	keys := changedKeys(oldConfig, newConfig)
+	if len(keys) == 0 {
+		summary.Duration = time.Since(start)
+		return summary, nil
+	}
+
+	// 需要重启的字段无法在线生效，补丁不能修改
+	probe := newConfig
+	if pending := m.preserveRestartFields(oldConfig, &probe); len(pending) > 0 {
+		sort.Strings(pending)
+		return fail(fmt.Errorf("%w: 字段 %s 需要重启才能生效", ErrPatchInvalid, formatKeys(pending)))
+	}
+
+	handlers := m.reloadHandlerList()
+	if err := prepareReload(handlers, oldConfig, newConfig); err != nil {
+		return fail(err)
+	}
+
+	// 写回成功后才替换配置；提交成功后才递增版本号，回滚时订阅者不会看到中间状态
+	applied := newConfig
+	warnings, err := m.replaceConfig(oldConfig, &applied)
+	m.executeHooks(warnings)
+	if err != nil {
+		rollbackReload(handlers, oldConfig, newConfig)
+		return fail(fmt.Errorf("写回配置补丁失败: %w", err))
+	}
+
+	if err := commitReload(handlers, oldConfig, newConfig); err != nil {
+		restored := oldConfig
+		warnings, restoreErr := m.replaceConfig(newConfig, &restored)
+		m.executeHooks(warnings)
+		if restoreErr != nil {
+			// 文件无法恢复时内存中的配置仍回到原值，与版本号保持一致
+			m.rwMutex.Lock()
+			m.config = &restored
+			m.rwMutex.Unlock()
+			err = errors.Join(err, fmt.Errorf("恢复配置文件失败: %w", restoreErr))
+		}
+		return fail(err)
+	}
+	m.markApplied(&applied)
+
+	summary.ChangedKeys = keys
+	summary.Applied, summary.ComponentErrors = m.applyComponents(oldConfig, newConfig, keys)
+	m.reportComponentFailures(summary.ComponentErrors)
+	summary.Duration = time.Since(start)
+	summary.Version = m.Version()
+
+	m.executeHook(Info, HookContext{
+		Message:     fmt.Sprintf("[config] 已应用配置补丁: %s", formatKeys(keys)),
+		Event:       EventPatchApplied,
+		File:        summary.File,
+		ChangedKeys: keys,
+		Duration:    summary.Duration,
+		Attrs:       []slog.Attr{slog.String("kind", string(kind))},
+	})
+	m.publishChange(TriggerPatch, fsnotify.Event{Name: summary.File, Op: fsnotify.Write}, oldConfig, summary)
+	return summary, nil
+}
+
+// DiffPatch 生成将 oldConfig 变为 newConfig 的 RFC 6902 JSON Patch
+// 映射按键比较，长度相同的切片按元素比较，长度不同时整体替换；
+// 生成的补丁可以直接交给 ApplyPatch，注意其中的 Secret 为真实值
+// 返回值：
+//
+//	[]byte: JSON Patch 文档（没有差异时为 []）
+//	error: 配置无法转换为 JSON 文档时返回错误
+func (m *Manager[T]) DiffPatch(oldConfig, newConfig T) ([]byte, error) {
+	oldDoc, err := configDocument(oldConfig)
+	if err != nil {
+		return nil, err
+	}
+	newDoc, err := configDocument(newConfig)
+	if err != nil {
+		return nil, err
+	}
+	ops := []PatchOperation{}
+	diffDocuments(oldDoc, newDoc, "", &ops)
+	return json.Marshal(ops)
+}
+
+// patchConfig 将补丁应用到配置的 JSON 文档形式，并按严格模式解码为新配置
+func (m *Manager[T]) patchConfig(current T, patch []byte, kind PatchKind) (T, error) {
+	var zero T
+	doc, err := configDocument(current)
+	if err != nil {
+		return zero, err
+	}
+
+	var patched any
+	switch kind {
+	case JSONPatch:
+		var ops []PatchOperation
+		if err := decodeJSON(patch, &ops); err != nil {
+			return zero, fmt.Errorf("%w: %v", ErrPatchInvalid, err)
+		}
+		if patched, err = applyJSONPatch(doc, ops); err != nil {
+			// test 操作失败时错误信息包含字段的当前值，按应用补丁前的文档脱敏
+			original, _ := configDocument(current)
+			settings, _ := original.(map[string]any)
+			return zero, redactSettingsError(err, settings, reflect.TypeFor[T]())
+		}
+	case MergePatch:
+		var merge any
+		if err := decodeJSON(patch, &merge); err != nil {
+			return zero, fmt.Errorf("%w: %v", ErrPatchInvalid, err)
+		}
+		patched = mergePatch(doc, merge)
+	default:
+		return zero, fmt.Errorf("%w: 未知的补丁格式 %q", ErrPatchInvalid, kind)
+	}
+
+	settings, ok := patched.(map[string]any)
+	if !ok {
+		return zero, fmt.Errorf("%w: 补丁应用后的配置不是对象", ErrPatchInvalid)
+	}
+	var newConfig T
+	if err := m.decode(settings, &newConfig, true); err != nil {
+		m.executeHook(Error, HookContext{
+			Message: fmt.Sprintf("[config] 补丁应用后的配置无效: %v", err),
+			Event:   EventValidationFailed,
+			Err:     err,
+		})
+		return zero, fmt.Errorf("%w: %w", ErrPatchInvalid, err)
+	}
+	return newConfig, nil
+}
+
+// configDocument 将配置转换为 JSON 文档形式（数字使用 json.Number 保留精度）
+func configDocument(cfg any) (any, error) {
+	data, err := json.Marshal(plainValue(reflect.ValueOf(cfg)))
+	if err != nil {
+		return nil, fmt.Errorf("序列化配置失败: %w", err)
+	}
+	var doc any
+	if err := decodeJSON(data, &doc); err != nil {
+		return nil, fmt.Errorf("序列化配置失败: %w", err)
+	}
+	return doc, nil
+}
+
+// decodeJSON 解析 JSON，数字解析为 json.Number
+func decodeJSON(data []byte, out any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(out)
+}
+
+// applyJSONPatch 按顺序执行 JSON Patch 操作，任一操作失败时返回错误
+func applyJSONPatch(doc any, ops []PatchOperation) (any, error) {
+	for i, op := range ops {
+		var err error
+		doc, err = applyPatchOperation(doc, op)
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 个操作 %s %s: %w", i+1, op.Op, op.Path, err)
+		}
+	}
+	return doc, nil
+}
+
+// applyPatchOperation 执行单个 JSON Patch 操作
+func applyPatchOperation(doc any, op PatchOperation) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch op.Op {
+	case "add":
+		return pointerAdd(doc, path, op.Value)
+	case "remove":
+		return pointerRemove(doc, path)
+	case "replace":
+		if _, err := pointerGet(doc, path); err != nil {
+			return nil, err
+		}
+		if doc, err = pointerRemove(doc, path); err != nil {
+			return nil, err
+		}
+		return pointerAdd(doc, path, op.Value)
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := pointerGet(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "copy" {
+			return pointerAdd(doc, path, copyDocument(value))
+		}
+		if len(path) > len(from) && slices.Equal(path[:len(from)], from) {
+			return nil, fmt.Errorf("%w: 不能移动到自身的子路径", ErrPatchInvalid)
+		}
+		if doc, err = pointerRemove(doc, from); err != nil {
+			return nil, err
+		}
+		return pointerAdd(doc, path, value)
+	case "test":
+		value, err := pointerGet(doc, path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrPatchTestFailed, err)
+		}
+		if !documentEqual(value, op.Value) {
+			return nil, fmt.Errorf("%w: 当前值为 %s", ErrPatchTestFailed, documentString(value))
+		}
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("%w: 未知的操作 %q", ErrPatchInvalid, op.Op)
+	}
+}
+
+// parsePointer 解析 RFC 6901 JSON Pointer
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: 无效的路径 %q", ErrPatchInvalid, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// pointerGet 读取路径对应的值
+func pointerGet(doc any, path []string) (any, error) {
+	for i, token := range path {
+		switch node := doc.(type) {
+		case map[string]any:
+			key, ok := findSettingKey(node, token)
+			if !ok {
+				return nil, fmt.Errorf("%w: 路径 %s 不存在", ErrPatchInvalid, formatPointer(path[:i+1]))
+			}
+			doc = node[key]
+		case []any:
+			idx, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[idx]
+		default:
+			return nil, fmt.Errorf("%w: 路径 %s 不存在", ErrPatchInvalid, formatPointer(path[:i+1]))
+		}
+	}
+	return doc, nil
+}
+
+// pointerAdd 在路径处添加值：对象中添加或替换键，数组中插入元素（"-" 表示末尾）
+func pointerAdd(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return updateParent(doc, path, func(parent any, token string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			key, ok := findSettingKey(node, token)
+			if !ok {
+				key = token
+			}
+			node[key] = value
+			return node, nil
+		case []any:
+			idx, err := arrayIndex(token, len(node), true)
+			if err != nil {
+				return nil, err
+			}
+			node = append(node, nil)
+			copy(node[idx+1:], node[idx:])
+			node[idx] = value
+			return node, nil
+		default:
+			return nil, fmt.Errorf("%w: 路径 %s 的父级不是对象或数组", ErrPatchInvalid, formatPointer(path))
+		}
+	})
+}
+
+// pointerRemove 移除路径对应的值
+func pointerRemove(doc any, path []string) (any, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("%w: 不能移除整个配置", ErrPatchInvalid)
+	}
+	return updateParent(doc, path, func(parent any, token string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			key, ok := findSettingKey(node, token)
+			if !ok {
+				return nil, fmt.Errorf("%w: 路径 %s 不存在", ErrPatchInvalid, formatPointer(path))
+			}
+			delete(node, key)
+			return node, nil
+		case []any:
+			idx, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			return append(node[:idx], node[idx+1:]...), nil
+		default:
+			return nil, fmt.Errorf("%w: 路径 %s 不存在", ErrPatchInvalid, formatPointer(path))
+		}
+	})
+}
+
+// updateParent 定位路径的父级并用 fn 的返回值替换它（数组插入或删除元素后需要替换）
+func updateParent(doc any, path []string, fn func(parent any, token string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(doc, path[0])
+	}
+	parentPath := path[:len(path)-1]
+	parent, err := pointerGet(doc, parentPath)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := fn(parent, path[len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	// 对象原地修改即可；数组长度可能变化，需要写回祖父级
+	if _, isArray := updated.([]any); !isArray {
+		return doc, nil
+	}
+	grandparent, err := pointerGet(doc, parentPath[:len(parentPath)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := parentPath[len(parentPath)-1]
+	switch node := grandparent.(type) {
+	case map[string]any:
+		key, _ := findSettingKey(node, last)
+		node[key] = updated
+	case []any:
+		idx, _ := arrayIndex(last, len(node), false)
+		node[idx] = updated
+	}
+	return doc, nil
+}
+
+// arrayIndex 解析数组下标；allowEnd 为 true 时允许 "-" 与等于长度的下标（用于插入）
+func arrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if token == "-" && allowEnd {
+		return length, nil
+	}
+	idx, err := strconv.Atoi(token)
+	if err != nil || idx < 0 || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, fmt.Errorf("%w: 无效的数组下标 %q", ErrPatchInvalid, token)
+	}
+	if idx > length || (idx == length && !allowEnd) {
+		return 0, fmt.Errorf("%w: 数组下标 %d 越界（长度 %d）", ErrPatchInvalid, idx, length)
+	}
+	return idx, nil
+}
+
+// mergePatch 执行 RFC 7396 Merge Patch：对象递归合并，null 表示删除，其他值直接替换
+func mergePatch(target, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObj, ok := target.(map[string]any)
+	if !ok {
+		targetObj = make(map[string]any)
+	}
+	for k, v := range patchObj {
+		key, found := findSettingKey(targetObj, k)
+		if !found {
+			key = k
+		}
+		if v == nil {
+			delete(targetObj, key)
+			continue
+		}
+		targetObj[key] = mergePatch(targetObj[key], v)
+	}
+	return targetObj
+}
+
+// diffDocuments 递归比较两个 JSON 文档并生成补丁操作
+func diffDocuments(oldDoc, newDoc any, pointer string, ops *[]PatchOperation) {
+	oldObj, oldIsObj := oldDoc.(map[string]any)
+	newObj, newIsObj := newDoc.(map[string]any)
+	if oldIsObj && newIsObj {
+		keys := make([]string, 0, len(oldObj)+len(newObj))
+		for k := range oldObj {
+			keys = append(keys, k)
+		}
+		for k := range newObj {
+			if _, ok := oldObj[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			child := pointer + "/" + escapePointer(k)
+			oldValue, inOld := oldObj[k]
+			newValue, inNew := newObj[k]
+			switch {
+			case !inNew:
+				*ops = append(*ops, PatchOperation{Op: "remove", Path: child})
+			case !inOld:
+				*ops = append(*ops, PatchOperation{Op: "add", Path: child, Value: newValue})
+			default:
+				diffDocuments(oldValue, newValue, child, ops)
+			}
+		}
+		return
+	}
+
+	oldArr, oldIsArr := oldDoc.([]any)
+	newArr, newIsArr := newDoc.([]any)
+	if oldIsArr && newIsArr && len(oldArr) == len(newArr) {
+		for i := range oldArr {
+			diffDocuments(oldArr[i], newArr[i], pointer+"/"+strconv.Itoa(i), ops)
+		}
+		return
+	}
+
+	if !documentEqual(oldDoc, newDoc) {
+		*ops = append(*ops, PatchOperation{Op: "replace", Path: pointer, Value: newDoc})
+	}
+}
+
+// documentEqual 比较两个 JSON 值（数字按数值比较）
+func documentEqual(a, b any) bool {
+	switch av := a.(type) {
+	case json.Number:
+		bv, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		if av == bv {
+			return true
+		}
+		af, errA := av.Float64()
+		bf, errB := bv.Float64()
+		return errA == nil && errB == nil && af == bf
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k, v := range av {
+			other, ok := bv[k]
+			if !ok || !documentEqual(v, other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !documentEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+// copyDocument 深拷贝 JSON 值
+func copyDocument(v any) any {
+	switch node := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(node))
+		for k, item := range node {
+			out[k] = copyDocument(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(node))
+		for i, item := range node {
+			out[i] = copyDocument(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// documentString 将 JSON 值格式化为简短的描述
+func documentString(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// escapePointer 按 RFC 6901 转义 JSON Pointer 中的一段
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// formatPointer 将路径片段还原为 JSON Pointer
+func formatPointer(path []string) string {
+	var b strings.Builder
+	for _, token := range path {
+		b.WriteString("/" + escapePointer(token))
+	}
+	return b.String()
+}
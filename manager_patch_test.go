@@ -0,0 +1,283 @@
+package configx
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type patchTestConfig struct {
+	Database struct {
+		MaxOpenConns int           `mapstructure:"max_open_conns"`
+		Timeout      time.Duration `mapstructure:"timeout"`
+		Password     Secret        `mapstructure:"password"`
+	} `mapstructure:"database"`
+	Servers []string          `mapstructure:"servers"`
+	Labels  map[string]string `mapstructure:"labels"`
+	Port    int               `mapstructure:"port" reload:"restart"`
+}
+
+const patchTestContent = `database:
+  max_open_conns: 10
+  timeout: 5s
+  password: secret
+servers: [a, b]
+labels:
+  zone: east
+port: 8080
+`
+
+// newPatchTestManager 创建已加载配置的管理器
+func newPatchTestManager(t *testing.T) *Manager[patchTestConfig] {
+	t.Helper()
+	manager := newTestManager[patchTestConfig](t, patchTestContent, nil)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	return manager
+}
+
+// TestApplyJSONPatch 测试 JSON Patch 的各类操作与写回
+func TestApplyJSONPatch(t *testing.T) {
+	manager := newPatchTestManager(t)
+	var events []*Context
+	manager.OnChange(func(ctx *Context) { events = append(events, ctx) })
+
+	summary, err := manager.ApplyPatch([]byte(`[
+		{"op": "test", "path": "/database/max_open_conns", "value": 10},
+		{"op": "replace", "path": "/database/max_open_conns", "value": 50},
+		{"op": "replace", "path": "/database/timeout", "value": "30s"},
+		{"op": "add", "path": "/servers/-", "value": "c"},
+		{"op": "remove", "path": "/servers/0"},
+		{"op": "copy", "from": "/labels/zone", "path": "/labels/region"},
+		{"op": "move", "from": "/labels/zone", "path": "/labels/area"}
+	]`), JSONPatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := manager.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Database.MaxOpenConns != 50 || cfg.Database.Timeout != 30*time.Second || cfg.Database.Password != "secret" {
+		t.Errorf("补丁应用后的配置错误: %+v", cfg.Database)
+	}
+	if !reflect.DeepEqual(cfg.Servers, []string{"b", "c"}) || !reflect.DeepEqual(cfg.Labels, map[string]string{"region": "east", "area": "east"}) {
+		t.Errorf("补丁应用后的配置错误: %v %v", cfg.Servers, cfg.Labels)
+	}
+	want := []string{"database.max_open_conns", "database.timeout", "labels.area", "labels.region", "labels.zone", "servers"}
+	if summary.Trigger != TriggerPatch || !reflect.DeepEqual(summary.ChangedKeys, want) {
+		t.Errorf("ChangeSummary 错误: %+v", summary)
+	}
+	if len(events) != 1 || events[0].Trigger != TriggerPatch {
+		t.Errorf("应分发一次变更事件: %d", len(events))
+	}
+
+	content, err := os.ReadFile(manager.vp.ConfigFileUsed())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"max_open_conns: 50\n", "30s", "area: east"} {
+		if !strings.Contains(string(content), s) {
+			t.Errorf("配置文件中缺少 %q:\n%s", s, content)
+		}
+	}
+}
+
+// TestApplyPatchFailures 测试 test 不匹配与无效补丁时保持原有配置
+func TestApplyPatchFailures(t *testing.T) {
+	manager := newPatchTestManager(t)
+	version := manager.Version()
+
+	cases := []struct {
+		patch string
+		kind  PatchKind
+		want  error
+	}{
+		{`[{"op": "test", "path": "/database/max_open_conns", "value": 11}, {"op": "replace", "path": "/port", "value": 1}]`, JSONPatch, ErrPatchTestFailed},
+		{`[{"op": "replace", "path": "/missing", "value": 1}]`, JSONPatch, ErrPatchInvalid},
+		{`[{"op": "add", "path": "/unknown", "value": 1}]`, JSONPatch, ErrPatchInvalid},
+		{`[{"op": "replace", "path": "/database/max_open_conns", "value": "many"}]`, JSONPatch, ErrPatchInvalid},
+		{`[{"op": "add", "path": "/servers/5", "value": "x"}]`, JSONPatch, ErrPatchInvalid},
+		{`{"port": 9090}`, MergePatch, ErrPatchInvalid},
+		{`not json`, MergePatch, ErrPatchInvalid},
+	}
+	for _, c := range cases {
+		if _, err := manager.ApplyPatch([]byte(c.patch), c.kind); !errors.Is(err, c.want) {
+			t.Errorf("%s: 期望 %v，实际: %v", c.patch, c.want, err)
+		}
+	}
+	if manager.Version() != version {
+		t.Error("失败的补丁不应修改配置")
+	}
+}
+
+// TestApplyMergePatch 测试 Merge Patch 的合并与删除
+func TestApplyMergePatch(t *testing.T) {
+	manager := newPatchTestManager(t)
+	if _, err := manager.ApplyPatch([]byte(`{"database": {"max_open_conns": 20}, "labels": {"zone": null, "tier": "web"}}`), MergePatch); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := manager.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Database.MaxOpenConns != 20 || cfg.Database.Timeout != 5*time.Second || !reflect.DeepEqual(cfg.Labels, map[string]string{"tier": "web"}) {
+		t.Errorf("Merge Patch 应用后的配置错误: %+v", cfg)
+	}
+
+	// 没有改变配置的补丁不产生变更
+	version := manager.Version()
+	summary, err := manager.ApplyPatch([]byte(`{"database": {"max_open_conns": 20}}`), MergePatch)
+	if err != nil || summary.Changed() || manager.Version() != version {
+		t.Errorf("无变化的补丁: %+v %v", summary, err)
+	}
+}
+
+// TestDiffPatch 测试生成的补丁可以还原目标配置
+func TestDiffPatch(t *testing.T) {
+	manager := newPatchTestManager(t)
+	oldCfg, err := manager.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	newCfg := oldCfg
+	newCfg.Database.MaxOpenConns = 30
+	newCfg.Servers = []string{"a", "b", "c"}
+	newCfg.Labels = map[string]string{"tier": "web"}
+
+	patch, err := manager.DiffPatch(oldCfg, newCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ops []PatchOperation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		t.Fatal(err)
+	}
+	if len(ops) != 4 {
+		t.Errorf("补丁操作数量错误: %s", patch)
+	}
+
+	if _, err := manager.ApplyPatch(patch, JSONPatch); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := manager.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cfg, newCfg) {
+		t.Errorf("应用 DiffPatch 后的配置不一致:\n%+v\n%+v", cfg, newCfg)
+	}
+
+	if empty, err := manager.DiffPatch(cfg, cfg); err != nil || string(empty) != "[]" {
+		t.Errorf("相同配置应生成空补丁: %s %v", empty, err)
+	}
+}
+
+type patchStringerConfig struct {
+	DB   stringerSection `mapstructure:"db"`
+	Name string          `mapstructure:"name"`
+}
+
+// TestApplyPatchStringerSection 测试实现 Stringer 的配置结构体在补丁文档中仍为对象
+func TestApplyPatchStringerSection(t *testing.T) {
+	manager := newTestManager[patchStringerConfig](t, "db:\n  host: localhost\n  port: 1\nname: a\n", nil)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.ApplyPatch([]byte(`{"name": "b", "db": {"port": 2}}`), MergePatch); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := manager.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Name != "b" || cfg.DB != (stringerSection{Host: "localhost", Port: 2}) {
+		t.Errorf("补丁应用后的配置错误: %+v", cfg)
+	}
+}
+
+// TestApplyPatchWithWatch 测试开启文件监听时补丁只产生一次变更
+func TestApplyPatchWithWatch(t *testing.T) {
+	opts := NewOption()
+	opts.DebounceDur.Set(OptionTimeDuration(20 * time.Millisecond))
+	manager := newTestManager[patchTestConfig](t, patchTestContent, opts)
+	triggers := make(chan ReloadTrigger, 8)
+	if err := manager.Init(func(ctx *Context) { triggers <- ctx.Trigger }); err != nil {
+		t.Fatal(err)
+	}
+	defer manager.Close()
+
+	version := manager.Version()
+	if _, err := manager.ApplyPatch([]byte(`{"database": {"max_open_conns": 20}}`), MergePatch); err != nil {
+		t.Fatal(err)
+	}
+	if got := <-triggers; got != TriggerPatch {
+		t.Errorf("期望 patch 事件，实际: %q", got)
+	}
+	// 等待文件监听处理写回产生的事件
+	select {
+	case got := <-triggers:
+		t.Errorf("写回配置文件不应再触发重载: %q", got)
+	case <-time.After(300 * time.Millisecond):
+	}
+	if manager.Version() != version+1 {
+		t.Errorf("版本号应只递增一次: %d -> %d", version, manager.Version())
+	}
+
+	// 外部修改仍然触发重载
+	content := strings.Replace(patchTestContent, "max_open_conns: 10", "max_open_conns: 30", 1)
+	if err := os.WriteFile(opts.File(), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-triggers:
+		if got != TriggerWatch {
+			t.Errorf("期望 watch 事件，实际: %q", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("外部修改未触发重载")
+	}
+	// 写入过程中可能读到不完整的文件，防抖窗口结束时的补发重载保证最终内容生效
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		cfg, _ := manager.GetConfig()
+		if cfg.Database.MaxOpenConns == 30 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("外部修改未生效: %d", cfg.Database.MaxOpenConns)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestApplyPatchTestRedactsSecret 测试 test 操作失败时错误信息不包含敏感字段的真实值
+func TestApplyPatchTestRedactsSecret(t *testing.T) {
+	manager := newPatchTestManager(t)
+	var logged []string
+	manager.AddHook(Error, func(ctx HookContext) { logged = append(logged, ctx.Message) })
+
+	for _, patch := range []string{
+		`[{"op": "test", "path": "/database/password", "value": "guess"}]`,
+		`[{"op": "test", "path": "/database", "value": {}}]`,
+	} {
+		_, err := manager.ApplyPatch([]byte(patch), JSONPatch)
+		if !errors.Is(err, ErrPatchTestFailed) {
+			t.Fatalf("期望 ErrPatchTestFailed，实际: %v", err)
+		}
+		if strings.Contains(err.Error(), `"secret"`) || !strings.Contains(err.Error(), Redacted) {
+			t.Errorf("错误信息应脱敏: %v", err)
+		}
+	}
+	for _, message := range logged {
+		if strings.Contains(message, `"secret"`) {
+			t.Errorf("钩子信息应脱敏: %s", message)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package configx
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// Get 按字段路径读取当前配置中的值
+// 路径格式与 Viper 一致（如 "database.max_open_conns"、"servers[2].host"），按 mapstructure 标签匹配、不区分大小写，
+// 可以经过以字符串为键的映射与切片下标
+// 返回值：
+//
+//	any: 字段值（与运行中的配置共享映射、切片等引用类型，不要修改）
+//	error: 配置未初始化时返回 ErrConfigNotInitialized，路径不存在时返回 ErrFieldNotFound
+func (m *Manager[T]) Get(path string) (any, error) {
+	if _, err := fieldPathType(reflect.TypeFor[T](), path); err != nil {
+		return nil, err
+	}
+	m.rwMutex.RLock()
+	defer m.rwMutex.RUnlock()
+	if m.config == nil {
+		return nil, ErrConfigNotInitialized
+	}
+	field, ok := lookupField(reflect.ValueOf(m.config), path)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrFieldNotFound, path)
+	}
+	return field.Interface(), nil
+}
+
+// Set 按字段路径修改配置并写回配置文件
+// 与 UpdateField 使用相同的更新流程（写锁、版本号、绑定值、插值与加密字段的写回规则）；
+// value 按配置解码规则转换为字段类型，如 "30s" 转换为 time.Duration、"10" 转换为 int；
+// 路径经过的映射与切片会被复制后修改，不影响此前通过 GetConfig、Get 取得的值
+// 返回值：
+//
+//	error: 路径不存在或下标越界时返回 ErrFieldNotFound，值无法转换为字段类型时返回 ErrInvalidConfigType
+//
+// 示例：
+//
+//	manager.Set("database.max_open_conns", 50)
+//	manager.Set("servers[2].host", "10.0.0.3")
+//	manager.Set("server.read_timeout", "30s")
+func (m *Manager[T]) Set(path string, value any) error {
+	fieldType, err := fieldPathType(reflect.TypeFor[T](), path)
+	if err != nil {
+		return err
+	}
+	coerced, err := m.coerceValue(value, fieldType)
+	if err != nil {
+		return fmt.Errorf("%w: 字段 %s: %w", ErrInvalidConfigType, path, err)
+	}
+
+	warnings, err := m.updateField(func(c *T) error {
+		if err := setField(reflect.ValueOf(c).Elem(), splitKeyPath(path), coerced); err != nil {
+			return fmt.Errorf("字段 %s: %w", path, err)
+		}
+		return nil
+	})
+	// 在锁外触发钩子，避免钩子中访问配置导致死锁
+	m.executeHooks(warnings)
+	return err
+}
+
+// Keys 返回当前配置中全部叶子字段的路径（按字母排序）
+// 结构体按 mapstructure 标签展开，映射按键展开，切片元素使用 [index]；
+// 空映射、空切片与 nil 指针作为叶子字段返回；配置未初始化时返回 nil
+func (m *Manager[T]) Keys() []string {
+	m.rwMutex.RLock()
+	defer m.rwMutex.RUnlock()
+	if m.config == nil {
+		return nil
+	}
+	var keys []string
+	collectFieldKeys(reflect.ValueOf(*m.config), "", &keys)
+	sort.Strings(keys)
+	return keys
+}
+
+// coerceValue 按配置解码规则将值转换为字段类型
+func (m *Manager[T]) coerceValue(value any, t reflect.Type) (reflect.Value, error) {
+	out := reflect.New(t)
+	if value != nil && reflect.TypeOf(value).AssignableTo(t) {
+		out.Elem().Set(reflect.ValueOf(value))
+		return out.Elem(), nil
+	}
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           out.Interface(),
+		WeaklyTypedInput: true,
+		DecodeHook:       m.decodeHook(),
+	})
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("创建解码器失败: %w", err)
+	}
+	if err := decoder.Decode(value); err != nil {
+		return reflect.Value{}, err
+	}
+	return out.Elem(), nil
+}
+
+// setField 按路径片段写入字段值
+// 路径经过的指针、映射与切片先复制再修改，全部片段有效后才替换，
+// 因此失败时配置保持不变，旧配置的浅拷贝也不会被修改
+func setField(v reflect.Value, segs []keySegment, value reflect.Value) error {
+	if len(segs) == 0 {
+		v.Set(value)
+		return nil
+	}
+	seg, rest := segs[0], segs[1:]
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		clone := reflect.New(v.Type().Elem())
+		if !v.IsNil() {
+			clone.Elem().Set(v.Elem())
+		}
+		if err := setField(clone.Elem(), segs, value); err != nil {
+			return err
+		}
+		v.Set(clone)
+		return nil
+	case reflect.Struct:
+		index, ok := findStructField(v.Type(), seg.name)
+		if _, isIndex := seg.index(); isIndex || !ok {
+			return fmt.Errorf("%w: %s", ErrFieldNotFound, seg.name)
+		}
+		return setField(v.FieldByIndex(index), rest, value)
+	case reflect.Map:
+		if _, isIndex := seg.index(); isIndex {
+			return fmt.Errorf("%w: [%d] 不能用于映射", ErrFieldNotFound, seg.idx)
+		}
+		key, ok := findMapKey(v, seg.name)
+		if !ok {
+			key = reflect.ValueOf(strings.ToLower(seg.name)).Convert(v.Type().Key())
+		}
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if existing := v.MapIndex(key); existing.IsValid() {
+			elem.Set(existing)
+		}
+		if err := setField(elem, rest, value); err != nil {
+			return err
+		}
+		clone := reflect.MakeMapWithSize(v.Type(), v.Len()+1)
+		iter := v.MapRange()
+		for iter.Next() {
+			clone.SetMapIndex(iter.Key(), iter.Value())
+		}
+		clone.SetMapIndex(key, elem)
+		v.Set(clone)
+		return nil
+	case reflect.Slice, reflect.Array:
+		idx, isIndex := seg.index()
+		if !isIndex || idx >= v.Len() {
+			return fmt.Errorf("%w: 下标 [%d] 越界（长度 %d）", ErrFieldNotFound, seg.idx, v.Len())
+		}
+		target := v
+		if v.Kind() == reflect.Slice {
+			target = reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+			reflect.Copy(target, v)
+		} else {
+			target = reflect.New(v.Type()).Elem()
+			target.Set(v)
+		}
+		if err := setField(target.Index(idx), rest, value); err != nil {
+			return err
+		}
+		v.Set(target)
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrFieldNotFound, seg.name)
+	}
+}
+
+// collectFieldKeys 递归收集叶子字段路径
+func collectFieldKeys(v reflect.Value, path string, out *[]string) {
+	if v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			if path != "" {
+				*out = append(*out, path)
+			}
+			return
+		}
+		if v.Kind() == reflect.Interface || !isLeafValue(v) {
+			collectFieldKeys(v.Elem(), path, out)
+			return
+		}
+	}
+
+	switch {
+	case v.Kind() == reflect.Struct && !isLeafValue(v):
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name, opts, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+			if name == "-" || strings.Contains(opts, "remain") {
+				continue
+			}
+			if strings.Contains(opts, "squash") || (f.Anonymous && name == "") {
+				collectFieldKeys(v.Field(i), path, out)
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			collectFieldKeys(v.Field(i), joinKeyPath(path, strings.ToLower(name)), out)
+		}
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String && v.Len() > 0:
+		iter := v.MapRange()
+		for iter.Next() {
+			collectFieldKeys(iter.Value(), joinKeyPath(path, iter.Key().String()), out)
+		}
+	case (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Len() > 0 && !isScalarBytes(v):
+		for i := 0; i < v.Len(); i++ {
+			collectFieldKeys(v.Index(i), path+"["+strconv.Itoa(i)+"]", out)
+		}
+	default:
+		*out = append(*out, path)
+	}
+}
+
+// isScalarBytes 判断是否为作为单个值处理的字节切片（如 net.IP）
+func isScalarBytes(v reflect.Value) bool {
+	return v.Type().Elem().Kind() == reflect.Uint8
+}
+
+// plainValue 将字段值转换为可编码到配置文件的普通值（映射、切片与标量）
+// 结构体按 mapstructure 标签转换为映射；Duration、URL 以及实现 TextMarshaler 的值使用可读形式；
+// 只实现 fmt.Stringer 的配置结构体仍转换为映射；Secret 写回真实值
+func plainValue(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+	}
+	if v.Type() == secretType {
+		return v.String()
+	}
+	if v.Kind() == reflect.Interface {
+		return plainValue(v.Elem())
+	}
+	if isTextType(v.Type()) || v.Type() == durationType {
+		return formatValue(v)
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		return plainValue(v.Elem())
+	case reflect.Struct:
+		out := make(map[string]any)
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name, opts, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+			if name == "-" || strings.Contains(opts, "remain") {
+				continue
+			}
+			if strings.Contains(opts, "squash") || (f.Anonymous && name == "") {
+				if nested, ok := plainValue(v.Field(i)).(map[string]any); ok {
+					for k, item := range nested {
+						out[k] = item
+					}
+				}
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			out[strings.ToLower(name)] = plainValue(v.Field(i))
+		}
+		return out
+	case reflect.Map:
+		out := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out[fmt.Sprint(iter.Key().Interface())] = plainValue(iter.Value())
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = plainValue(v.Index(i))
+		}
+		return out
+	default:
+		return v.Interface()
+	}
+}
+
+// durationType time.Duration 没有实现 TextMarshaler，但写回时使用可读形式（如 "30s"）
+var durationType = reflect.TypeOf(time.Duration(0))
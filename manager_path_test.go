@@ -0,0 +1,153 @@
+package configx
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type pathTestServer struct {
+	Host string `mapstructure:"host"`
+	Port int    `mapstructure:"port"`
+}
+
+type pathTestConfig struct {
+	Database struct {
+		MaxOpenConns int           `mapstructure:"max_open_conns"`
+		Timeout      time.Duration `mapstructure:"timeout"`
+	} `mapstructure:"database"`
+	Servers []pathTestServer  `mapstructure:"servers"`
+	Labels  map[string]string `mapstructure:"labels"`
+}
+
+const pathTestContent = `# 数据库
+database:
+  max_open_conns: 10
+  timeout: 5s
+servers:
+  - host: a
+    port: 1
+  - host: b
+    port: 2
+labels:
+  zone: east
+`
+
+// TestPathGetAndKeys 测试按路径读取与列出字段
+func TestPathGetAndKeys(t *testing.T) {
+	manager := newTestManager[pathTestConfig](t, pathTestContent, nil)
+	if _, err := manager.Get("database.max_open_conns"); !errors.Is(err, ErrConfigNotInitialized) {
+		t.Errorf("未加载时期望 ErrConfigNotInitialized，实际: %v", err)
+	}
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string]any{
+		"database.max_open_conns": 10,
+		"Database.Timeout":        5 * time.Second,
+		"servers[1].host":         "b",
+		"labels.zone":             "east",
+	} {
+		got, err := manager.Get(path)
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("Get(%q) = %v, %v，期望 %v", path, got, err, want)
+		}
+	}
+	for _, path := range []string{"database.missing", "servers[5].host", "labels.missing", "servers.host"} {
+		if _, err := manager.Get(path); !errors.Is(err, ErrFieldNotFound) {
+			t.Errorf("Get(%q) 期望 ErrFieldNotFound，实际: %v", path, err)
+		}
+	}
+
+	want := []string{
+		"database.max_open_conns", "database.timeout", "labels.zone",
+		"servers[0].host", "servers[0].port", "servers[1].host", "servers[1].port",
+	}
+	if keys := manager.Keys(); !reflect.DeepEqual(keys, want) {
+		t.Errorf("Keys 错误: %v", keys)
+	}
+}
+
+// TestPathSet 测试按路径修改、类型转换与写回文件
+func TestPathSet(t *testing.T) {
+	manager := newTestManager[pathTestConfig](t, pathTestContent, nil)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	before, err := manager.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	version := manager.Version()
+
+	if err := manager.Set("database.max_open_conns", "50"); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.Set("database.timeout", "30s"); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.Set("servers[1].host", "c"); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.Set("labels.region", "cn"); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := manager.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Database.MaxOpenConns != 50 || cfg.Database.Timeout != 30*time.Second || cfg.Servers[1].Host != "c" || cfg.Labels["region"] != "cn" {
+		t.Errorf("Set 后的配置错误: %+v", cfg)
+	}
+	if before.Servers[1].Host != "b" || manager.Version() != version+4 {
+		t.Errorf("Set 不应修改已取得的副本，且每次递增版本号: %+v %d", before, manager.Version())
+	}
+
+	content, err := os.ReadFile(manager.vp.ConfigFileUsed())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "# 数据库") {
+		t.Errorf("写回应保留注释:\n%s", content)
+	}
+	var written pathTestConfig
+	var raw map[string]any
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.decode(raw, &written, false); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(written, cfg) {
+		t.Errorf("写回的文件与内存中的配置不一致:\n%s", content)
+	}
+}
+
+// TestPathSetErrors 测试无效路径与类型转换失败
+func TestPathSetErrors(t *testing.T) {
+	manager := newTestManager[pathTestConfig](t, pathTestContent, nil)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	version := manager.Version()
+
+	if err := manager.Set("database.missing", 1); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("期望 ErrFieldNotFound，实际: %v", err)
+	}
+	if err := manager.Set("servers[2].host", "x"); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("下标越界期望 ErrFieldNotFound，实际: %v", err)
+	}
+	if err := manager.Set("database.max_open_conns", "many"); !errors.Is(err, ErrInvalidConfigType) {
+		t.Errorf("期望 ErrInvalidConfigType，实际: %v", err)
+	}
+	if manager.Version() != version {
+		t.Error("失败的 Set 不应修改配置")
+	}
+}
@@ -0,0 +1,345 @@
+package configx
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// ReloadHandler 两阶段热重载处理器
+// 配置文件变更并解析成功后：
+//   - Prepare：按注册顺序调用，可返回错误否决新配置（如新的 DSN 无法连接数据库）
+//   - 全部 Prepare 通过后替换当前配置
+//   - Commit：按注册顺序调用，应用新配置
+//   - Rollback：Prepare 被否决或 Commit 失败时，按逆序通知已经 Prepare 成功的处理器
+//
+// 任一阶段失败时当前配置保持（或恢复为）原有配置
+type ReloadHandler[T any] interface {
+	Prepare(oldConfig, newConfig T) error
+	Commit(oldConfig, newConfig T) error
+	Rollback(oldConfig, newConfig T)
+}
+
+// ReloadFuncs 函数形式的 ReloadHandler，未设置的阶段视为成功
+type ReloadFuncs[T any] struct {
+	PrepareFunc  func(oldConfig, newConfig T) error
+	CommitFunc   func(oldConfig, newConfig T) error
+	RollbackFunc func(oldConfig, newConfig T)
+}
+
+// Prepare 实现 ReloadHandler 接口
+func (f ReloadFuncs[T]) Prepare(oldConfig, newConfig T) error {
+	if f.PrepareFunc == nil {
+		return nil
+	}
+	return f.PrepareFunc(oldConfig, newConfig)
+}
+
+// Commit 实现 ReloadHandler 接口
+func (f ReloadFuncs[T]) Commit(oldConfig, newConfig T) error {
+	if f.CommitFunc == nil {
+		return nil
+	}
+	return f.CommitFunc(oldConfig, newConfig)
+}
+
+// Rollback 实现 ReloadHandler 接口
+func (f ReloadFuncs[T]) Rollback(oldConfig, newConfig T) {
+	if f.RollbackFunc != nil {
+		f.RollbackFunc(oldConfig, newConfig)
+	}
+}
+
+// reloadEntry 已注册的热重载处理器
+type reloadEntry[T any] struct {
+	id      uint64
+	handler ReloadHandler[T]
+}
+
+// OnReload 注册两阶段热重载处理器
+// 处理器只参与热重载，不参与首次加载
+// 返回值：
+//
+//	*HookHandle: 注册句柄，调用 Remove() 注销该处理器
+//
+// 示例：
+//
+//	manager.OnReload(configx.ReloadFuncs[AppConfig]{
+//	    PrepareFunc: func(oldCfg, newCfg AppConfig) error {
+//	        if oldCfg.Database.DSN == newCfg.Database.DSN {
+//	            return nil
+//	        }
+//	        pool, err := db.Open(newCfg.Database.DSN)
+//	        pending = pool
+//	        return err
+//	    },
+//	    CommitFunc: func(oldCfg, newCfg AppConfig) error {
+//	        swapPool(pending)
+//	        return nil
+//	    },
+//	    RollbackFunc: func(oldCfg, newCfg AppConfig) {
+//	        closePending()
+//	    },
+//	})
+func (m *Manager[T]) OnReload(handler ReloadHandler[T]) *HookHandle {
+	m.reloadMutex.Lock()
+	m.reloadNextID++
+	id := m.reloadNextID
+	m.reloadHandlers = append(m.reloadHandlers, reloadEntry[T]{id: id, handler: handler})
+	m.reloadMutex.Unlock()
+
+	return &HookHandle{remove: func() {
+		m.reloadMutex.Lock()
+		defer m.reloadMutex.Unlock()
+		for i, entry := range m.reloadHandlers {
+			if entry.id == id {
+				m.reloadHandlers = append(append([]reloadEntry[T](nil), m.reloadHandlers[:i]...), m.reloadHandlers[i+1:]...)
+				return
+			}
+		}
+	}}
+}
+
+// reloadHandlerList 返回已注册处理器的快照
+func (m *Manager[T]) reloadHandlerList() []ReloadHandler[T] {
+	m.reloadMutex.RLock()
+	defer m.reloadMutex.RUnlock()
+	handlers := make([]ReloadHandler[T], len(m.reloadHandlers))
+	for i, entry := range m.reloadHandlers {
+		handlers[i] = entry.handler
+	}
+	return handlers
+}
+
+// prepareReload 执行 Prepare 阶段
+// 任一处理器否决时，按逆序通知已经 Prepare 成功的处理器回滚
+func prepareReload[T any](handlers []ReloadHandler[T], oldConfig, newConfig T) error {
+	for i, handler := range handlers {
+		if err := handler.Prepare(oldConfig, newConfig); err != nil {
+			rollbackReload(handlers[:i], oldConfig, newConfig)
+			return fmt.Errorf("%w: 第 %d 个处理器: %w", ErrReloadVetoed, i+1, err)
+		}
+	}
+	return nil
+}
+
+// commitReload 执行 Commit 阶段
+// 任一处理器失败时，全部处理器按逆序收到回滚通知：
+// 已经 Commit 的处理器需要撤销，尚未 Commit 的处理器需要释放 Prepare 阶段准备的资源
+func commitReload[T any](handlers []ReloadHandler[T], oldConfig, newConfig T) error {
+	for i, handler := range handlers {
+		if err := handler.Commit(oldConfig, newConfig); err != nil {
+			rollbackReload(handlers, oldConfig, newConfig)
+			return fmt.Errorf("%w: 第 %d 个处理器: %w", ErrReloadRolledBack, i+1, err)
+		}
+	}
+	return nil
+}
+
+// rollbackReload 按逆序调用处理器的 Rollback
+func rollbackReload[T any](handlers []ReloadHandler[T], oldConfig, newConfig T) {
+	for i := len(handlers) - 1; i >= 0; i-- {
+		handlers[i].Rollback(oldConfig, newConfig)
+	}
+}
+
+// ReloadTrigger 触发热重载的来源
+type ReloadTrigger string
+
+const (
+	// TriggerWatch 文件监听检测到配置文件变更
+	TriggerWatch ReloadTrigger = "watch"
+	// TriggerManual 调用 Reload
+	TriggerManual ReloadTrigger = "manual"
+)
+
+// ChangeSummary 一次热重载的结果
+type ChangeSummary struct {
+	// Trigger 触发来源
+	Trigger ReloadTrigger
+	// File 配置文件
+	File string
+	// ChangedKeys 值发生变化的配置字段路径
+	ChangedKeys []string
+	// PendingRestart 配置文件中已修改、等待重启生效的字段路径
+	PendingRestart []string
+	// Applied 成功应用新配置的组件
+	Applied []string
+	// ComponentErrors 应用新配置失败的组件
+	ComponentErrors []*ComponentError
+	// Duration 重载耗时
+	Duration time.Duration
+	// Version 重载后的配置版本号
+	Version uint64
+}
+
+// Changed 判断本次重载是否改变了配置
+func (s ChangeSummary) Changed() bool {
+	return len(s.ChangedKeys) > 0
+}
+
+// Reload 立即重新读取并应用配置文件
+// 执行与文件监听相同的流程：读取 → 解析校验 → 两阶段处理器 → 替换配置 → 组件与回调通知，
+// 适用于由 SIGHUP、管理接口或部署钩子驱动重载的场景（可配合 Option.DisableWatch 使用）
+// 参数：
+//
+//	ctx: 在读取与解析完成、替换配置之前取消时放弃本次重载
+//
+// 返回值：
+//
+//	ChangeSummary: 重载结果
+//	error: 读取、解析、被否决或提交失败时返回错误，此时保持原有配置
+func (m *Manager[T]) Reload(ctx context.Context) (ChangeSummary, error) {
+	// 设置 Viper 的配置文件路径与写回、热重载串行，避免与其读取路径并发
+	m.reloadLock.Lock()
+	err := m.setupViper()
+	file := m.vp.ConfigFileUsed()
+	m.reloadLock.Unlock()
+	if err != nil {
+		return ChangeSummary{}, err
+	}
+	return m.reload(ctx, TriggerManual, fsnotify.Event{Name: file, Op: fsnotify.Write})
+}
+
+// reload 热重载流程（同一时间只执行一次，保证回调按顺序看到事件）
+// 变更在释放 reloadLock 后分发，回调中可以调用 Reload、ApplyPatch 与 UpdateField
+func (m *Manager[T]) reload(ctx context.Context, trigger ReloadTrigger, e fsnotify.Event) (ChangeSummary, error) {
+	defer m.flushChanges()
+	m.reloadLock.Lock()
+	defer m.reloadLock.Unlock()
+
+	start := time.Now()
+	summary := ChangeSummary{Trigger: trigger, File: e.Name}
+	fail := func(message string, err error) (ChangeSummary, error) {
+		summary.Duration = time.Since(start)
+		m.executeHook(Error, HookContext{
+			Message:  fmt.Sprintf("%s: %v", message, err),
+			Event:    EventReloadFailed,
+			File:     e.Name,
+			Duration: summary.Duration,
+			Err:      err,
+			Attrs:    []slog.Attr{slog.String("trigger", string(trigger))},
+		})
+		return summary, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return fail("[config] 重新加载已取消", err)
+	}
+
+	// 保存当前配置，以便在重载失败时恢复
+	m.rwMutex.RLock()
+	oldConfig := m.config
+	var oldValue T
+	if oldConfig != nil {
+		oldValue = *oldConfig
+	}
+	m.rwMutex.RUnlock()
+
+	// 重新加载配置文件（哈希在读取前计算，供定时重载判断内容是否变化）
+	hash, _ := fileHash(m.vp.ConfigFileUsed())
+	warnings, err := m.readConfig()
+	m.executeHooks(warnings)
+	if err != nil {
+		return fail("[config] 重新加载配置文件失败", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return fail("[config] 重新加载已取消", err)
+	}
+
+	// 解析配置到结构体
+	result, err := m.unmarshal()
+	if err != nil {
+		// 解析失败，恢复原有配置
+		m.rwMutex.Lock()
+		m.config = oldConfig
+		m.rwMutex.Unlock()
+		return fail("[config] 应用新配置失败，保持原有配置", err)
+	}
+	m.storeAppliedHash(hash)
+	result.Trigger, result.File = summary.Trigger, summary.File
+	summary = result
+	summary.Duration = time.Since(start)
+	summary.Version = m.Version()
+
+	// 触发钩子：配置重新加载成功
+	m.executeHook(Info, HookContext{
+		Message:     "[config] 配置重新加载成功",
+		Event:       EventReloadOK,
+		File:        e.Name,
+		ChangedKeys: summary.ChangedKeys,
+		Duration:    summary.Duration,
+		Attrs:       []slog.Attr{slog.String("trigger", string(trigger))},
+	})
+
+	m.publishChange(trigger, e, oldValue, summary)
+	return summary, nil
+}
+
+// pendingChange 等待分发的配置变更
+type pendingChange[T any] struct {
+	fsEvent  fsnotify.Event
+	sequence uint64
+	event    ChangeEvent[T]
+}
+
+// publishChange 将一次已生效的配置变更加入分发队列（在 reloadLock 内调用，保证事件顺序与生效顺序一致）
+// 调用方释放 reloadLock 后调用 flushChanges 执行分发
+func (m *Manager[T]) publishChange(trigger ReloadTrigger, e fsnotify.Event, oldValue T, summary ChangeSummary) {
+	m.rwMutex.RLock()
+	newValue := *m.config
+	m.rwMutex.RUnlock()
+
+	m.publishMutex.Lock()
+	m.publishQueue = append(m.publishQueue, pendingChange[T]{
+		fsEvent:  e,
+		sequence: m.changeSeq.Add(1),
+		event: ChangeEvent[T]{
+			Old:         oldValue,
+			New:         newValue,
+			ChangedKeys: summary.ChangedKeys,
+			Version:     summary.Version,
+			Trigger:     trigger,
+			Time:        time.Now(),
+		},
+	})
+	m.publishMutex.Unlock()
+}
+
+// flushChanges 按顺序将队列中的变更分发给 OnChange 回调与 Watch 订阅者
+// 同一时间只有一个调用方执行分发：已有分发在进行时（例如在同步回调中调用 Reload、ApplyPatch、UpdateField），
+// 新的变更由正在分发的调用方在当前事件之后分发，本次调用直接返回，不会因等待自身所在的回调而死锁
+func (m *Manager[T]) flushChanges() {
+	m.publishMutex.Lock()
+	if m.publishing {
+		m.publishMutex.Unlock()
+		return
+	}
+	m.publishing = true
+	for len(m.publishQueue) > 0 {
+		change := m.publishQueue[0]
+		m.publishQueue = m.publishQueue[1:]
+		m.publishMutex.Unlock()
+
+		// 执行开发者提供的回调函数
+		m.dispatchChange(func() *Context {
+			// 创建回调上下文，包含管理器实例引用
+			return &Context{
+				FSEvent:     change.fsEvent,
+				Trigger:     change.event.Trigger,
+				Sequence:    change.sequence,
+				ChangedKeys: change.event.ChangedKeys,
+				manager:     m,
+			}
+		})
+		// 通知 Watch 订阅者
+		m.notifyWatchers(change.event)
+
+		m.publishMutex.Lock()
+	}
+	m.publishing = false
+	m.publishMutex.Unlock()
+}
@@ -0,0 +1,214 @@
+package configx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+type reloadTestConfig struct {
+	DSN string `mapstructure:"dsn"`
+}
+
+// reloadTestFile 写入新的配置内容并模拟一次热重载
+func reloadTestFile[T any](t *testing.T, manager *Manager[T], content string) error {
+	t.Helper()
+	if err := os.WriteFile(manager.opts.File(), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.readConfig(); err != nil {
+		t.Fatal(err)
+	}
+	_, err := manager.unmarshal()
+	return err
+}
+
+// recordingHandler 记录调用顺序的两阶段处理器
+func recordingHandler(name string, calls *[]string, prepareErr, commitErr error) ReloadFuncs[reloadTestConfig] {
+	return ReloadFuncs[reloadTestConfig]{
+		PrepareFunc: func(oldCfg, newCfg reloadTestConfig) error {
+			*calls = append(*calls, name+".prepare")
+			return prepareErr
+		},
+		CommitFunc: func(oldCfg, newCfg reloadTestConfig) error {
+			*calls = append(*calls, name+".commit")
+			return commitErr
+		},
+		RollbackFunc: func(oldCfg, newCfg reloadTestConfig) {
+			*calls = append(*calls, name+".rollback")
+		},
+	}
+}
+
+// TestReloadPrepareVeto 测试 Prepare 阶段否决新配置
+func TestReloadPrepareVeto(t *testing.T) {
+	manager := newTestManager[reloadTestConfig](t, "dsn: old\n", nil)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	var calls []string
+	manager.OnReload(recordingHandler("a", &calls, nil, nil))
+	manager.OnReload(recordingHandler("b", &calls, errors.New("cannot connect"), nil))
+	manager.OnReload(recordingHandler("c", &calls, nil, nil))
+
+	err := reloadTestFile(t, manager, "dsn: new\n")
+	if !errors.Is(err, ErrReloadVetoed) {
+		t.Fatalf("期望 ErrReloadVetoed，实际: %v", err)
+	}
+	if got := strings.Join(calls, ","); got != "a.prepare,b.prepare,a.rollback" {
+		t.Errorf("调用顺序错误: %s", got)
+	}
+	if manager.config.DSN != "old" {
+		t.Errorf("被否决时应保持原有配置: %s", manager.config.DSN)
+	}
+}
+
+// TestReloadCommitRollback 测试 Commit 失败时恢复原有配置并通知回滚
+func TestReloadCommitRollback(t *testing.T) {
+	manager := newTestManager[reloadTestConfig](t, "dsn: old\n", nil)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	var calls []string
+	var seen string
+	manager.OnReload(ReloadFuncs[reloadTestConfig]{
+		CommitFunc: func(oldCfg, newCfg reloadTestConfig) error {
+			// Commit 阶段当前配置已经是新配置
+			cfg, _ := manager.GetConfig()
+			seen = cfg.DSN
+			return nil
+		},
+	})
+	manager.OnReload(recordingHandler("a", &calls, nil, nil))
+	manager.OnReload(recordingHandler("b", &calls, nil, errors.New("apply failed")))
+
+	err := reloadTestFile(t, manager, "dsn: new\n")
+	if !errors.Is(err, ErrReloadRolledBack) {
+		t.Fatalf("期望 ErrReloadRolledBack，实际: %v", err)
+	}
+	if seen != "new" {
+		t.Errorf("Commit 阶段应能读取到新配置: %s", seen)
+	}
+	if got := strings.Join(calls, ","); got != "a.prepare,b.prepare,a.commit,b.commit,b.rollback,a.rollback" {
+		t.Errorf("调用顺序错误: %s", got)
+	}
+	if manager.config.DSN != "old" {
+		t.Errorf("回滚后应恢复原有配置: %s", manager.config.DSN)
+	}
+}
+
+// TestReloadHandlerRemove 测试注销处理器及成功的热重载
+func TestReloadHandlerRemove(t *testing.T) {
+	manager := newTestManager[reloadTestConfig](t, "dsn: old\n", nil)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	var calls []string
+	handle := manager.OnReload(recordingHandler("a", &calls, errors.New("veto"), nil))
+	handle.Remove()
+	manager.OnReload(recordingHandler("b", &calls, nil, nil))
+
+	if err := reloadTestFile(t, manager, "dsn: new\n"); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(calls, ","); got != "b.prepare,b.commit" {
+		t.Errorf("调用顺序错误: %s", got)
+	}
+	if manager.config.DSN != "new" {
+		t.Errorf("应用新配置失败: %s", manager.config.DSN)
+	}
+}
+
+// TestManualReload 测试关闭文件监听后通过 Reload 触发重载
+func TestManualReload(t *testing.T) {
+	opts := NewOption()
+	opts.DisableWatch = true
+	manager := newTestManager[reloadTestConfig](t, "dsn: old\n", opts)
+
+	var trigger ReloadTrigger
+	if err := manager.Init(func(ctx *Context) { trigger = ctx.Trigger }); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(opts.File(), []byte("dsn: new\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := manager.Reload(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !summary.Changed() || strings.Join(summary.ChangedKeys, ",") != "dsn" || summary.Trigger != TriggerManual {
+		t.Errorf("ChangeSummary 错误: %+v", summary)
+	}
+	if trigger != TriggerManual {
+		t.Errorf("回调应收到 manual 触发来源: %q", trigger)
+	}
+	if cfg, _ := manager.GetConfig(); cfg.DSN != "new" {
+		t.Errorf("Reload 未生效: %s", cfg.DSN)
+	}
+
+	// 已取消的 ctx 不执行重载
+	if err := os.WriteFile(opts.File(), []byte("dsn: canceled\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := manager.Reload(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("期望 context.Canceled，实际: %v", err)
+	}
+	if cfg, _ := manager.GetConfig(); cfg.DSN != "new" {
+		t.Errorf("取消后应保持原有配置: %s", cfg.DSN)
+	}
+}
+
+// TestReloadFromHandler 测试在同步回调中调用 ApplyPatch 不会死锁，且事件按生效顺序分发
+func TestReloadFromHandler(t *testing.T) {
+	opts := NewOption()
+	opts.DisableWatch = true
+	manager := newTestManager[reloadTestConfig](t, "dsn: old\n", opts)
+
+	var triggers []ReloadTrigger
+	var patchErr error
+	if err := manager.Init(func(ctx *Context) {
+		triggers = append(triggers, ctx.Trigger)
+		if ctx.Trigger == TriggerManual {
+			_, patchErr = manager.ApplyPatch([]byte(`{"dsn": "patched"}`), MergePatch)
+		}
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(opts.File(), []byte("dsn: new\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := manager.Reload(context.Background())
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("在回调中调用 ApplyPatch 导致死锁")
+	}
+
+	if patchErr != nil {
+		t.Fatalf("回调中应用补丁失败: %v", patchErr)
+	}
+	if !slices.Equal(triggers, []ReloadTrigger{TriggerManual, TriggerPatch}) {
+		t.Errorf("事件顺序错误: %v", triggers)
+	}
+	if cfg, _ := manager.GetConfig(); cfg.DSN != "patched" {
+		t.Errorf("补丁未生效: %s", cfg.DSN)
+	}
+}
@@ -0,0 +1,201 @@
+package configx
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ReloadTag 标记热重载时不能在线生效的字段，如 `reload:"restart"`
+const ReloadTag = "reload"
+
+// reloadRestart ReloadTag 的取值：修改后需要重启才能生效
+const reloadRestart = "restart"
+
+// RequireRestart 注册修改后需要重启才能生效的配置字段路径
+// 与 `reload:"restart"` 标签等价，适用于无法修改结构体定义的场景；
+// 注册父字段（如 "server"）时其全部子字段都需要重启
+// 返回值：
+//
+//	*Manager[T]: 返回管理器实例以支持链式调用
+func (m *Manager[T]) RequireRestart(paths ...string) *Manager[T] {
+	m.restartMutex.Lock()
+	defer m.restartMutex.Unlock()
+	for _, p := range paths {
+		m.restartKeys = append(m.restartKeys, strings.ToLower(p))
+	}
+	return m
+}
+
+// PendingRestart 返回配置文件与运行中配置不一致、等待重启生效的字段路径
+// 结果基于最近一次热重载，按字母排序
+func (m *Manager[T]) PendingRestart() []string {
+	if keys := m.pendingRestart.Load(); keys != nil {
+		return append([]string(nil), (*keys)...)
+	}
+	return nil
+}
+
+// restartKeyList 返回已注册的重启字段快照
+func (m *Manager[T]) restartKeyList() []string {
+	m.restartMutex.RLock()
+	defer m.restartMutex.RUnlock()
+	return append([]string(nil), m.restartKeys...)
+}
+
+// preserveRestartFields 将需要重启的字段恢复为运行中的值
+// 参数：
+//
+//	oldConfig: 运行中的配置
+//	newConfig: 从配置文件解析的新配置（被直接修改）
+//
+// 返回值：
+//
+//	[]string: 配置文件中已修改、但保留运行中值的字段路径
+func (m *Manager[T]) preserveRestartFields(oldConfig T, newConfig *T) []string {
+	var pending []string
+	preserveValue(reflect.ValueOf(oldConfig), reflect.ValueOf(newConfig).Elem(), "", m.restartKeyList(), false, &pending)
+	return pending
+}
+
+// preserveValue 递归比较字段，需要重启的字段如有变化则恢复为旧值
+func preserveValue(oldVal, newVal reflect.Value, path string, registered []string, tagged bool, out *[]string) {
+	if tagged || restartRegistered(path, registered) {
+		if !reflect.DeepEqual(oldVal.Interface(), newVal.Interface()) {
+			newVal.Set(oldVal)
+			*out = append(*out, path)
+		}
+		return
+	}
+
+	switch {
+	case oldVal.Kind() == reflect.Pointer:
+		if oldVal.IsNil() && newVal.IsNil() {
+			return
+		}
+		// 指针一侧为 nil 时按零值比较：新增的指针中需要重启的字段保持零值，
+		// 被删除的指针中需要重启的字段保留运行中的值；在副本上修改，不影响解析结果共享的值
+		elemType := oldVal.Type().Elem()
+		oldElem := reflect.Zero(elemType)
+		if !oldVal.IsNil() {
+			oldElem = oldVal.Elem()
+		}
+		newElem := reflect.New(elemType)
+		if !newVal.IsNil() {
+			newElem.Elem().Set(newVal.Elem())
+		}
+		count := len(*out)
+		preserveValue(oldElem, newElem.Elem(), path, registered, false, out)
+		if len(*out) > count {
+			newVal.Set(newElem)
+		}
+	case oldVal.Kind() == reflect.Struct && !isLeafValue(oldVal):
+		t := oldVal.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name, opts, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+			if name == "-" {
+				continue
+			}
+			fieldPath := path
+			if !strings.Contains(opts, "squash") && !(f.Anonymous && name == "") && !strings.Contains(opts, "remain") {
+				if name == "" {
+					name = f.Name
+				}
+				fieldPath = joinKeyPath(path, strings.ToLower(name))
+			}
+			fieldTagged := f.Tag.Get(ReloadTag) == reloadRestart
+			preserveValue(oldVal.Field(i), newVal.Field(i), fieldPath, registered, fieldTagged, out)
+		}
+	case oldVal.Kind() == reflect.Map && oldVal.Type().Key().Kind() == reflect.String:
+		preserveMapEntries(oldVal, newVal, path, registered, out)
+	case oldVal.Kind() == reflect.Slice || oldVal.Kind() == reflect.Array:
+		preserveElements(oldVal, newVal, path, registered, out)
+	}
+}
+
+// preserveElements 按下标处理切片与数组元素中需要重启的字段（如 "listeners[0].port"）
+// 增删的元素按零值比较，其中需要重启的字段有变化时整个切片保留运行中的值
+func preserveElements(oldVal, newVal reflect.Value, path string, registered []string, out *[]string) {
+	elems := newVal
+	if newVal.Kind() == reflect.Slice {
+		// 在副本上修改，不影响解析结果共享的底层数组
+		elems = reflect.MakeSlice(newVal.Type(), newVal.Len(), newVal.Len())
+		reflect.Copy(elems, newVal)
+	}
+
+	var pending []string
+	common := min(oldVal.Len(), elems.Len())
+	for i := 0; i < common; i++ {
+		preserveValue(oldVal.Index(i), elems.Index(i), path+"["+strconv.Itoa(i)+"]", registered, false, &pending)
+	}
+	for i := common; i < max(oldVal.Len(), elems.Len()); i++ {
+		oldElem := reflect.Zero(elems.Type().Elem())
+		newElem := reflect.New(elems.Type().Elem()).Elem()
+		if i < oldVal.Len() {
+			oldElem = oldVal.Index(i)
+		} else {
+			newElem.Set(elems.Index(i))
+		}
+		var resized []string
+		preserveValue(oldElem, newElem, path+"["+strconv.Itoa(i)+"]", registered, false, &resized)
+		if len(resized) > 0 {
+			newVal.Set(oldVal)
+			*out = append(*out, path)
+			return
+		}
+	}
+
+	if len(pending) > 0 {
+		newVal.Set(elems)
+		*out = append(*out, pending...)
+	}
+}
+
+// preserveMapEntries 处理注册到映射元素上的重启字段（如 "listeners.public"）
+func preserveMapEntries(oldVal, newVal reflect.Value, path string, registered []string, out *[]string) {
+	seen := make(map[string]bool)
+	for _, key := range append(oldVal.MapKeys(), newVal.MapKeys()...) {
+		if seen[key.String()] {
+			continue
+		}
+		seen[key.String()] = true
+
+		entryPath := joinKeyPath(path, strings.ToLower(key.String()))
+		if !restartRegistered(entryPath, registered) {
+			continue
+		}
+		oldEntry, newEntry := oldVal.MapIndex(key), newVal.MapIndex(key)
+		if oldEntry.IsValid() && newEntry.IsValid() && reflect.DeepEqual(oldEntry.Interface(), newEntry.Interface()) {
+			continue
+		}
+		if newVal.IsNil() {
+			newVal.Set(reflect.MakeMap(newVal.Type()))
+		}
+		// oldEntry 无效时删除新增的元素
+		newVal.SetMapIndex(key, oldEntry)
+		*out = append(*out, entryPath)
+	}
+}
+
+// restartRegistered 判断字段路径是否属于注册的重启字段（相同或为其子字段、元素）
+func restartRegistered(path string, registered []string) bool {
+	if path == "" {
+		return false
+	}
+	for _, r := range registered {
+		if path == r || strings.HasPrefix(path, r+".") || strings.HasPrefix(path, r+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// restartMessage 生成需要重启的提示信息
+func restartMessage(file string, keys []string) string {
+	return fmt.Sprintf("[config] 配置文件 %s 中的字段 %s 需要重启才能生效，当前仍使用运行中的值", file, formatKeys(keys))
+}
@@ -0,0 +1,172 @@
+package configx
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+type restartTestConfig struct {
+	Server struct {
+		Port    int    `mapstructure:"port" reload:"restart"`
+		Timeout string `mapstructure:"timeout"`
+	} `mapstructure:"server"`
+	Database struct {
+		Driver string `mapstructure:"driver"`
+	} `mapstructure:"database"`
+}
+
+// TestRestartFieldsPreserved 测试需要重启的字段在热重载时保留运行中的值
+func TestRestartFieldsPreserved(t *testing.T) {
+	manager := newTestManager[restartTestConfig](t, "server:\n  port: 80\n  timeout: 1s\ndatabase:\n  driver: mysql\n", nil)
+	manager.RequireRestart("Database.Driver")
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	var warned HookContext
+	manager.AddHook(Warn, func(ctx HookContext) { warned = ctx })
+
+	if err := reloadTestFile(t, manager, "server:\n  port: 8080\n  timeout: 5s\ndatabase:\n  driver: postgres\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, _ := manager.GetConfig()
+	if cfg.Server.Port != 80 || cfg.Database.Driver != "mysql" {
+		t.Errorf("需要重启的字段不应在线生效: %+v", cfg)
+	}
+	if cfg.Server.Timeout != "5s" {
+		t.Errorf("其他字段应正常生效: %+v", cfg)
+	}
+	if got := strings.Join(manager.PendingRestart(), ","); got != "database.driver,server.port" {
+		t.Errorf("PendingRestart = %q", got)
+	}
+	if warned.Event != EventRestartRequired || len(warned.ChangedKeys) != 2 {
+		t.Errorf("应触发 restart_required 事件: %+v", warned)
+	}
+
+	// 配置文件恢复后不再有待重启字段
+	if err := reloadTestFile(t, manager, "server:\n  port: 80\n  timeout: 5s\ndatabase:\n  driver: mysql\n"); err != nil {
+		t.Fatal(err)
+	}
+	if pending := manager.PendingRestart(); len(pending) != 0 {
+		t.Errorf("PendingRestart 应为空: %v", pending)
+	}
+}
+
+// TestRestartMapEntry 测试注册到映射元素上的重启字段
+func TestRestartMapEntry(t *testing.T) {
+	type config struct {
+		Listeners map[string]string `mapstructure:"listeners"`
+	}
+	manager := newTestManager[config](t, "listeners:\n  public: \":80\"\n  admin: \":81\"\n", nil)
+	manager.RequireRestart("listeners.public")
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := reloadTestFile(t, manager, "listeners:\n  public: \":8080\"\n  admin: \":8081\"\n"); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ := manager.GetConfig()
+	if cfg.Listeners["public"] != ":80" || cfg.Listeners["admin"] != ":8081" {
+		t.Errorf("映射元素处理错误: %v", cfg.Listeners)
+	}
+}
+
+// TestRestartNilPointer 测试指针由 nil 变为非 nil 时其中需要重启的字段保留运行中的值
+func TestRestartNilPointer(t *testing.T) {
+	type tls struct {
+		Cert string `mapstructure:"cert" reload:"restart"`
+		Mode string `mapstructure:"mode"`
+	}
+	type config struct {
+		Name string `mapstructure:"name"`
+		TLS  *tls   `mapstructure:"tls"`
+	}
+	manager := newTestManager[config](t, "name: a\n", nil)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := reloadTestFile(t, manager, "name: b\ntls:\n  cert: server.pem\n  mode: strict\n"); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ := manager.GetConfig()
+	if cfg.TLS == nil || cfg.TLS.Cert != "" || cfg.TLS.Mode != "strict" {
+		t.Errorf("新增指针中需要重启的字段应保持零值: %+v", cfg.TLS)
+	}
+	if got := strings.Join(manager.PendingRestart(), ","); got != "tls.cert" {
+		t.Errorf("PendingRestart = %q", got)
+	}
+}
+
+// TestRestartLoadConfig 测试再次调用 LoadConfig 时同样保留需要重启的字段
+func TestRestartLoadConfig(t *testing.T) {
+	manager := newTestManager[restartTestConfig](t, "server:\n  port: 80\n  timeout: 1s\n", nil)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	var warned HookContext
+	manager.AddHook(Warn, func(ctx HookContext) { warned = ctx })
+	if err := os.WriteFile(manager.vp.ConfigFileUsed(), []byte("server:\n  port: 8080\n  timeout: 5s\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, _ := manager.GetConfig()
+	if cfg.Server.Port != 80 || cfg.Server.Timeout != "5s" {
+		t.Errorf("LoadConfig 不应修改需要重启的字段: %+v", cfg)
+	}
+	if got := strings.Join(manager.PendingRestart(), ","); got != "server.port" {
+		t.Errorf("PendingRestart = %q", got)
+	}
+	if warned.Event != EventRestartRequired {
+		t.Errorf("应触发 restart_required 事件: %+v", warned)
+	}
+}
+
+// TestRestartSliceElements 测试切片元素中需要重启的字段
+func TestRestartSliceElements(t *testing.T) {
+	type listener struct {
+		Name string `mapstructure:"name"`
+		Port int    `mapstructure:"port" reload:"restart"`
+	}
+	type config struct {
+		Listeners []listener `mapstructure:"listeners"`
+	}
+	manager := newTestManager[config](t, "listeners:\n  - name: a\n    port: 80\n", nil)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	running, _ := manager.GetConfig()
+
+	if err := reloadTestFile(t, manager, "listeners:\n  - name: b\n    port: 8080\n"); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ := manager.GetConfig()
+	if cfg.Listeners[0].Port != 80 || cfg.Listeners[0].Name != "b" {
+		t.Errorf("切片元素中需要重启的字段不应在线生效: %+v", cfg.Listeners)
+	}
+	if got := strings.Join(manager.PendingRestart(), ","); got != "listeners[0].port" {
+		t.Errorf("PendingRestart = %q", got)
+	}
+
+	// 新增的元素中需要重启的字段有变化时整个切片保留运行中的值
+	if err := reloadTestFile(t, manager, "listeners:\n  - name: b\n    port: 80\n  - name: c\n    port: 81\n"); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ = manager.GetConfig()
+	if len(cfg.Listeners) != 1 || cfg.Listeners[0].Name != "b" {
+		t.Errorf("增加元素时应保留运行中的切片: %+v", cfg.Listeners)
+	}
+	if got := strings.Join(manager.PendingRestart(), ","); got != "listeners" {
+		t.Errorf("PendingRestart = %q", got)
+	}
+	if running.Listeners[0].Name != "a" {
+		t.Errorf("不应修改之前的配置: %+v", running.Listeners)
+	}
+}
@@ -0,0 +1,187 @@
+package configx
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// TriggerInterval Option.ReloadInterval 定时重载
+const TriggerInterval ReloadTrigger = "interval"
+
+// defaultBackoffFactor 未设置 ReloadMaxBackoff 时，退避上限为重载间隔的倍数
+const defaultBackoffFactor = 10
+
+// reloadInterval 返回当前的定时重载间隔
+// 设置了 ReloadIntervalKey 且当前配置中存在该字段时使用其值（为 0 表示暂停定时重载）
+func (m *Manager[T]) reloadInterval(opts *Option) time.Duration {
+	if opts.ReloadIntervalKey != "" {
+		if interval := m.configInterval.Load(); interval != nil {
+			return *interval
+		}
+	}
+	return opts.ReloadInterval
+}
+
+// recordInterval 记录 ReloadIntervalKey 字段在新生效配置中的值（由 markApplied 调用）
+// 取自解析后的配置，被否决、校验失败或回滚的重载不会改变重载间隔；
+// T 中不存在该字段、路径上存在 nil 指针或类型不支持时使用 Option.ReloadInterval
+func (m *Manager[T]) recordInterval(cfg *T) {
+	key := m.options().ReloadIntervalKey
+	if key == "" {
+		return
+	}
+	field, ok := lookupField(reflect.ValueOf(cfg), key)
+	for ok && field.Kind() == reflect.Pointer {
+		ok = !field.IsNil()
+		if ok {
+			field = field.Elem()
+		}
+	}
+	if ok {
+		if interval, ok := durationValue(field); ok {
+			m.configInterval.Store(&interval)
+			return
+		}
+	}
+	m.configInterval.Store(nil)
+}
+
+// durationValue 将 time.Duration、整数（纳秒）或时长字符串字段转换为 time.Duration
+func durationValue(v reflect.Value) (time.Duration, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return time.Duration(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return time.Duration(v.Uint()), true
+	case reflect.String:
+		d, err := time.ParseDuration(v.String())
+		return d, err == nil
+	}
+	return 0, false
+}
+
+// startScheduler 启动定时重载（重载间隔为 0 时不启动）
+func (m *Manager[T]) startScheduler(opts *Option) {
+	m.schedulerEnabled.Store(true)
+	m.resumeScheduler()
+}
+
+// resumeScheduler 定时重载未运行且重载间隔大于 0 时启动定时重载
+// 每次应用新配置后调用：通过 ReloadIntervalKey 将间隔从 0 改为正数时重新启动
+func (m *Manager[T]) resumeScheduler() {
+	if !m.schedulerEnabled.Load() || m.isClosed() || m.schedulerRunning.Load() {
+		return
+	}
+	opts := m.options()
+	if m.reloadInterval(opts) <= 0 || !m.schedulerRunning.CompareAndSwap(false, true) {
+		return
+	}
+	go m.runScheduler(opts)
+}
+
+// storeAppliedHash 记录当前配置对应的配置文件内容哈希（为空表示读取文件失败，不记录）
+func (m *Manager[T]) storeAppliedHash(hash string) {
+	if hash != "" {
+		m.appliedHash.Store(&hash)
+	}
+}
+
+// runScheduler 定时读取配置文件，内容与当前配置对应的哈希相同时跳过解析
+// 文件监听、Reload、LoadConfig 与写回都会更新该哈希，已应用的内容不会被重复重载
+// 重载失败时按指数退避延长间隔，成功后恢复
+func (m *Manager[T]) runScheduler(opts *Option) {
+	file := m.vp.ConfigFileUsed()
+	failures := 0
+
+	for {
+		interval := m.reloadInterval(opts)
+		if interval <= 0 {
+			// 配置中关闭了定时重载；退出前再次检查，避免与 resumeScheduler 交错导致无人运行
+			m.schedulerRunning.Store(false)
+			if m.reloadInterval(opts) > 0 && m.schedulerRunning.CompareAndSwap(false, true) {
+				continue
+			}
+			return
+		}
+		timer := time.NewTimer(scheduleDelay(interval, opts, failures))
+		select {
+		case <-m.closed:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		hash, err := fileHash(file)
+		if err != nil {
+			failures++
+			m.executeHook(Error, HookContext{
+				Message: fmt.Sprintf("[config] 定时读取配置文件失败: %v", err),
+				Event:   EventReloadFailed,
+				File:    file,
+				Err:     err,
+				Attrs:   []slog.Attr{slog.String("trigger", string(TriggerInterval)), slog.Int("failures", failures)},
+			})
+			continue
+		}
+		if applied := m.appliedHash.Load(); applied != nil && hash == *applied && failures == 0 {
+			m.executeHook(Debug, HookContext{
+				Message: fmt.Sprintf("[config] 配置文件 %s 内容未变化，跳过重载", file),
+				Event:   EventReloadSkipped,
+				File:    file,
+				Attrs:   []slog.Attr{slog.String("trigger", string(TriggerInterval))},
+			})
+			continue
+		}
+
+		if _, err := m.reload(context.Background(), TriggerInterval, fsnotify.Event{Name: file, Op: fsnotify.Write}); err != nil {
+			failures++
+			continue
+		}
+		failures = 0
+	}
+}
+
+// scheduleDelay 计算下一次定时重载的等待时间
+// 失败时间隔按 2^failures 增长，不超过 ReloadMaxBackoff；ReloadJitter 为随机抖动比例
+func scheduleDelay(interval time.Duration, opts *Option, failures int) time.Duration {
+	delay := interval
+	if failures > 0 {
+		limit := opts.ReloadMaxBackoff
+		if limit <= 0 {
+			limit = interval * defaultBackoffFactor
+		}
+		for i := 0; i < failures && delay < limit; i++ {
+			delay *= 2
+		}
+		delay = min(delay, limit)
+	}
+	if opts.ReloadJitter > 0 {
+		jitter := min(opts.ReloadJitter, 1)
+		delay = time.Duration(float64(delay) * (1 + jitter*(rand.Float64()*2-1)))
+	}
+	return max(delay, time.Millisecond)
+}
+
+// fileHash 计算文件内容的 SHA-256 哈希
+func fileHash(file string) (string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	return contentHash(data), nil
+}
+
+// contentHash 计算内容的 SHA-256 哈希
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
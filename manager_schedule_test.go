@@ -0,0 +1,196 @@
+package configx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type scheduleTestConfig struct {
+	DSN    string `mapstructure:"dsn"`
+	Reload struct {
+		Interval time.Duration `mapstructure:"interval"`
+	} `mapstructure:"reload"`
+}
+
+// TestScheduleDelay 测试退避与抖动
+func TestScheduleDelay(t *testing.T) {
+	opts := &Option{ReloadMaxBackoff: 5 * time.Second}
+	cases := map[int]time.Duration{0: time.Second, 1: 2 * time.Second, 2: 4 * time.Second, 5: 5 * time.Second}
+	for failures, want := range cases {
+		if got := scheduleDelay(time.Second, opts, failures); got != want {
+			t.Errorf("failures=%d: got %v, want %v", failures, got, want)
+		}
+	}
+
+	opts.ReloadJitter = 0.1
+	for i := 0; i < 100; i++ {
+		if got := scheduleDelay(time.Second, opts, 0); got < 900*time.Millisecond || got > 1100*time.Millisecond {
+			t.Fatalf("抖动超出范围: %v", got)
+		}
+	}
+}
+
+// TestPeriodicReload 测试定时重载、内容未变化时跳过以及从配置更新间隔
+func TestPeriodicReload(t *testing.T) {
+	opts := NewOption()
+	opts.DisableWatch = true
+	opts.ReloadInterval = time.Hour
+	opts.ReloadIntervalKey = "reload.interval"
+	manager := newTestManager[scheduleTestConfig](t, "dsn: v1\nreload:\n  interval: 10ms\n", opts)
+
+	reloaded := make(chan struct{}, 10)
+	skipped := make(chan struct{}, 100)
+	manager.AddHook(Info, func(ctx HookContext) {
+		if ctx.Event == EventReloadOK {
+			reloaded <- struct{}{}
+		}
+	})
+	manager.AddHook(Debug, func(ctx HookContext) {
+		if ctx.Event == EventReloadSkipped {
+			select {
+			case skipped <- struct{}{}:
+			default:
+			}
+		}
+	})
+	if err := manager.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer manager.Close()
+
+	select {
+	case <-skipped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("内容未变化时应跳过重载（间隔应取自配置）")
+	}
+
+	if err := os.WriteFile(opts.File(), []byte("dsn: v2\nreload:\n  interval: 10ms\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-reloaded:
+	case <-time.After(2 * time.Second):
+		t.Fatal("等待定时重载超时")
+	}
+	if cfg, _ := manager.GetConfig(); cfg.DSN != "v2" {
+		t.Errorf("定时重载未生效: %s", cfg.DSN)
+	}
+}
+
+// TestPeriodicReloadSkipsApplied 测试其他方式已应用的内容不会被定时重载重复加载
+func TestPeriodicReloadSkipsApplied(t *testing.T) {
+	opts := NewOption()
+	opts.DisableWatch = true
+	opts.ReloadIntervalKey = "reload.interval"
+	manager := newTestManager[scheduleTestConfig](t, "dsn: v1\nreload:\n  interval: 10ms\n", opts)
+
+	var intervalReloads atomic.Int32
+	manager.AddHook(Info, func(ctx HookContext) {
+		for _, attr := range ctx.Attrs {
+			if ctx.Event == EventReloadOK && attr.Key == "trigger" && attr.Value.String() == string(TriggerInterval) {
+				intervalReloads.Add(1)
+			}
+		}
+	})
+	skipped := make(chan struct{}, 100)
+	manager.AddHook(Debug, func(ctx HookContext) {
+		if ctx.Event == EventReloadSkipped {
+			select {
+			case skipped <- struct{}{}:
+			default:
+			}
+		}
+	})
+	if err := manager.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer manager.Close()
+
+	// 等待定时重载开始运行
+	select {
+	case <-skipped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("等待定时重载超时")
+	}
+
+	if err := os.WriteFile(opts.File(), []byte("dsn: v2\nreload:\n  interval: 10ms\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.Reload(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if n := intervalReloads.Load(); n != 0 {
+		t.Errorf("Reload 已应用的内容不应再被定时重载加载: %d 次", n)
+	}
+}
+
+// TestPeriodicReloadResume 测试通过配置将间隔从 0 改为正数后重新启动定时重载
+func TestPeriodicReloadResume(t *testing.T) {
+	opts := NewOption()
+	opts.DisableWatch = true
+	opts.ReloadIntervalKey = "reload.interval"
+	manager := newTestManager[scheduleTestConfig](t, "dsn: v1\nreload:\n  interval: 0s\n", opts)
+	if err := manager.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer manager.Close()
+
+	if err := os.WriteFile(opts.File(), []byte("dsn: v2\nreload:\n  interval: 10ms\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.Reload(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(opts.File(), []byte("dsn: v3\nreload:\n  interval: 10ms\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if cfg, _ := manager.GetConfig(); cfg.DSN == "v3" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("间隔改为正数后应重新启动定时重载")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestReloadIntervalFromAppliedConfig 测试重载间隔取自已生效的配置，被否决的重载不改变间隔
+func TestReloadIntervalFromAppliedConfig(t *testing.T) {
+	opts := NewOption()
+	opts.DisableWatch = true
+	opts.ReloadIntervalKey = "reload.interval"
+	manager := newTestManager[scheduleTestConfig](t, "dsn: v1\nreload:\n  interval: 1h\n", opts)
+	if err := manager.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer manager.Close()
+
+	veto := manager.OnReload(ReloadFuncs[scheduleTestConfig]{
+		PrepareFunc: func(_, _ scheduleTestConfig) error { return errors.New("veto") },
+	})
+	if err := os.WriteFile(opts.File(), []byte("dsn: v2\nreload:\n  interval: 2h\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.Reload(context.Background()); err == nil {
+		t.Fatal("重载应被否决")
+	}
+	if got := manager.reloadInterval(opts); got != time.Hour {
+		t.Errorf("被否决的重载不应改变间隔: %v", got)
+	}
+	veto.Remove()
+
+	if _, err := manager.ApplyPatch([]byte(`{"reload": {"interval": "3h"}}`), MergePatch); err != nil {
+		t.Fatal(err)
+	}
+	if got := manager.reloadInterval(opts); got != 3*time.Hour {
+		t.Errorf("补丁修改后的间隔错误: %v", got)
+	}
+}
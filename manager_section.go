@@ -0,0 +1,156 @@
+package configx
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ConfigSection 配置中某个子树的类型化视图
+// 由 Section 创建，方法签名只涉及子树类型 S，
+// 拥有各自配置子树的包可以只依赖自己的部分，而不必引用根配置类型
+type ConfigSection[S any] struct {
+	path     string
+	value    *Value[S]
+	loaded   func() bool
+	update   func(fn func(*S)) error
+	snapshot func() (S, error)
+}
+
+// Section 创建配置子树的视图
+// 参数：
+//
+//	m: 配置管理器
+//	path: 子树路径（如 "database"、"server.tls"），只能经过结构体字段与指针
+//
+// 返回值：
+//
+//	*ConfigSection[S]: 子树视图
+//	error: 路径不存在或经过映射、切片时返回 ErrFieldNotFound，字段类型不是 S 时返回 ErrInvalidConfigType
+//
+// 示例：
+//
+//	// 在 database 包中只依赖 DatabaseConfig
+//	func New(section *configx.ConfigSection[DatabaseConfig]) *Pool {
+//	    pool := open(section.Get())
+//	    section.OnChange(func(oldCfg, newCfg DatabaseConfig) { pool.Resize(newCfg.PoolSize) })
+//	    return pool
+//	}
+//
+//	db, err := configx.Section[database.DatabaseConfig](manager, "database")
+func Section[S, T any](m *Manager[T], path string) (*ConfigSection[S], error) {
+	if err := sectionPathType[S](reflect.TypeFor[T](), path); err != nil {
+		return nil, err
+	}
+	selector := func(c *T) S {
+		var zero S
+		field, ok := lookupField(reflect.ValueOf(c), path)
+		if !ok {
+			return zero
+		}
+		value, _ := field.Interface().(S)
+		return value
+	}
+
+	return &ConfigSection[S]{
+		path:  path,
+		value: Bind(m, selector),
+		loaded: func() bool {
+			m.rwMutex.RLock()
+			defer m.rwMutex.RUnlock()
+			return m.config != nil
+		},
+		update: func(fn func(*S)) error {
+			// 路径上存在 nil 指针时在写回前返回错误，不递增版本号
+			warnings, err := m.updateField(func(c *T) error {
+				field, ok := lookupField(reflect.ValueOf(c), path)
+				if !ok || !field.CanAddr() {
+					return fmt.Errorf("%w: %s 为 nil", ErrFieldNotFound, path)
+				}
+				fn(field.Addr().Interface().(*S))
+				return nil
+			})
+			// 在锁外触发钩子，避免钩子中访问配置导致死锁
+			m.executeHooks(warnings)
+			return err
+		},
+		snapshot: func() (S, error) {
+			m.rwMutex.RLock()
+			defer m.rwMutex.RUnlock()
+			if m.config == nil {
+				var zero S
+				return zero, ErrConfigNotInitialized
+			}
+			return cloneValue(selector(m.config))
+		},
+	}, nil
+}
+
+// Path 返回子树路径
+func (s *ConfigSection[S]) Path() string {
+	return s.path
+}
+
+// Get 返回当前生效的子树配置（原子读取，配置尚未加载时为零值）
+// 返回值与运行中的配置共享映射、切片等引用类型，不要修改；需要修改时使用 Snapshot 或 Update
+func (s *ConfigSection[S]) Get() S {
+	return s.value.Load()
+}
+
+// Snapshot 返回子树配置的深拷贝
+// 如果 S 实现了 Cloneable[S] 接口，将使用自定义的 Clone() 方法，否则使用 JSON 序列化深拷贝
+// 返回值：
+//
+//	S: 子树配置副本
+//	error: 配置未初始化时返回 ErrConfigNotInitialized
+func (s *ConfigSection[S]) Snapshot() (S, error) {
+	return s.snapshot()
+}
+
+// OnChange 注册子树配置变化时的回调
+// 只在该子树的值发生变化时调用，语义与 Value.OnChange 相同
+// 返回值：
+//
+//	*HookHandle: 注册句柄，调用 Remove() 注销回调
+func (s *ConfigSection[S]) OnChange(fn func(oldCfg, newCfg S)) *HookHandle {
+	return s.value.OnChange(fn)
+}
+
+// Update 修改子树配置并写回配置文件，语义与 Manager.UpdateField 相同
+// 返回值：
+//
+//	error: 配置未初始化、路径上存在 nil 指针或写回失败时返回错误
+func (s *ConfigSection[S]) Update(fn func(*S)) error {
+	if !s.loaded() {
+		return ErrConfigNotInitialized
+	}
+	return s.update(fn)
+}
+
+// Close 解除子树视图与管理器的绑定，管理器关闭时自动解除
+func (s *ConfigSection[S]) Close() {
+	s.value.Close()
+}
+
+// sectionPathType 校验子树路径：只能经过结构体字段与指针，且字段类型为 S
+func sectionPathType[S any](root reflect.Type, path string) error {
+	segs := splitKeyPath(path)
+	if len(segs) == 0 {
+		return fmt.Errorf("%w: %q", ErrFieldNotFound, path)
+	}
+	t := root
+	for i, seg := range segs {
+		t = indirectType(t)
+		if _, isIndex := seg.index(); isIndex || t.Kind() != reflect.Struct {
+			return fmt.Errorf("%w: %s 不是结构体字段", ErrFieldNotFound, segmentPath(segs[:i+1]))
+		}
+		index, ok := findStructField(t, seg.name)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrFieldNotFound, segmentPath(segs[:i+1]))
+		}
+		t = t.FieldByIndex(index).Type
+	}
+	if target := reflect.TypeFor[S](); t != target {
+		return fmt.Errorf("%w: 字段 %s 的类型为 %s，而不是 %s", ErrInvalidConfigType, path, t, target)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package configx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+type sectionDatabase struct {
+	Host     string         `mapstructure:"host"`
+	Password Secret         `mapstructure:"password"`
+	Options  map[string]int `mapstructure:"options"`
+}
+
+type sectionTestConfig struct {
+	Name     string          `mapstructure:"name"`
+	Database sectionDatabase `mapstructure:"database"`
+	Cache    *struct {
+		Size int `mapstructure:"size"`
+	} `mapstructure:"cache"`
+}
+
+// TestSectionGetAndOnChange 测试子树视图只接收自身的变化
+func TestSectionGetAndOnChange(t *testing.T) {
+	opts := NewOption()
+	opts.DisableWatch = true
+	manager := newTestManager[sectionTestConfig](t, "name: a\ndatabase:\n  host: db1\n  password: p\n  options:\n    pool: 1\n", opts)
+	if err := manager.Init(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { manager.Close() })
+
+	db, err := Section[sectionDatabase](manager, "Database")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := db.Get(); got.Host != "db1" || got.Password.Reveal() != "p" {
+		t.Errorf("Get 错误: %+v", got)
+	}
+
+	changes := make(chan sectionDatabase, 4)
+	db.OnChange(func(oldCfg, newCfg sectionDatabase) { changes <- newCfg })
+
+	reload := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(opts.File(), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := manager.Reload(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reload("name: b\ndatabase:\n  host: db1\n  password: p\n  options:\n    pool: 1\n")
+	reload("name: b\ndatabase:\n  host: db2\n  password: p\n  options:\n    pool: 1\n")
+
+	select {
+	case got := <-changes:
+		if got.Host != "db2" {
+			t.Errorf("OnChange 参数错误: %+v", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("未收到 OnChange 回调")
+	}
+	select {
+	case got := <-changes:
+		t.Errorf("不应收到子树之外的变化: %+v", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// TestSectionSnapshotAndUpdate 测试深拷贝与写回
+func TestSectionSnapshotAndUpdate(t *testing.T) {
+	manager := newTestManager[sectionTestConfig](t, "name: a\ndatabase:\n  host: db1\n  password: p\n  options:\n    pool: 1\n", nil)
+	db, err := Section[sectionDatabase](manager, "database")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Snapshot(); !errors.Is(err, ErrConfigNotInitialized) {
+		t.Errorf("未加载时期望 ErrConfigNotInitialized，实际: %v", err)
+	}
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot, err := db.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot.Options["pool"] = 99
+	if db.Get().Options["pool"] != 1 || snapshot.Password.Reveal() != "p" {
+		t.Errorf("Snapshot 应为保留 Secret 的深拷贝: %+v", snapshot)
+	}
+
+	if err := db.Update(func(s *sectionDatabase) { s.Host = "db3" }); err != nil {
+		t.Fatal(err)
+	}
+	if db.Get().Host != "db3" {
+		t.Errorf("Update 后的值错误: %q", db.Get().Host)
+	}
+	content, err := os.ReadFile(manager.vp.ConfigFileUsed())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "db3") {
+		t.Errorf("Update 应写回文件:\n%s", content)
+	}
+}
+
+// TestSectionErrors 测试无效的子树路径
+func TestSectionErrors(t *testing.T) {
+	manager := newTestManager[sectionTestConfig](t, "name: a\n", nil)
+	if _, err := Section[sectionDatabase](manager, "missing"); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("期望 ErrFieldNotFound，实际: %v", err)
+	}
+	if _, err := Section[int](manager, "database.options.pool"); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("经过映射的路径期望 ErrFieldNotFound，实际: %v", err)
+	}
+	if _, err := Section[string](manager, "database"); !errors.Is(err, ErrInvalidConfigType) {
+		t.Errorf("期望 ErrInvalidConfigType，实际: %v", err)
+	}
+
+	cache, err := Section[int](manager, "cache.size")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	version := manager.Version()
+	if err := cache.Update(func(size *int) { *size = 1 }); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("经过 nil 指针时期望 ErrFieldNotFound，实际: %v", err)
+	}
+	if manager.Version() != version {
+		t.Errorf("更新失败时版本号不应变化: %d -> %d", version, manager.Version())
+	}
+	if data, _ := os.ReadFile(manager.vp.ConfigFileUsed()); string(data) != "name: a\n" {
+		t.Errorf("更新失败时不应写回配置文件:\n%s", data)
+	}
+}
+
+// TestSectionUpdateThroughPointer 测试经过指针字段的子树在写回前不修改运行中的配置
+func TestSectionUpdateThroughPointer(t *testing.T) {
+	manager := newTestManager[sectionTestConfig](t, "name: a\ncache:\n  size: 1\n", nil)
+	size, err := Section[int](manager, "cache.size")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := manager.Watch(ctx)
+	running := manager.config
+
+	version := manager.Version()
+	if err := size.Update(func(s *int) { *s = 2 }); err != nil {
+		t.Fatal(err)
+	}
+	if running.Cache.Size != 1 {
+		t.Errorf("Update 不应原地修改之前的配置: %d", running.Cache.Size)
+	}
+	if size.Get() != 2 || manager.Version() != version+1 {
+		t.Errorf("Update 后的值或版本号错误: %d %d", size.Get(), manager.Version())
+	}
+	if content, _ := os.ReadFile(manager.vp.ConfigFileUsed()); !strings.Contains(string(content), "size: 2") {
+		t.Errorf("Update 应写回文件:\n%s", content)
+	}
+	select {
+	case event := <-events:
+		if event.Old.Cache.Size != 1 || event.New.Cache.Size != 2 {
+			t.Errorf("事件内容错误: %+v -> %+v", event.Old.Cache, event.New.Cache)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("应发送变更事件")
+	}
+}
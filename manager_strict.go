@@ -0,0 +1,307 @@
+package configx
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// UnknownKey 严格模式下检测到的未知字段
+type UnknownKey struct {
+	// Path 字段路径，如 database.max_open_con
+	Path string
+	// File 字段所在的配置文件
+	File string
+	// Line 字段所在行号（无法定位时为 0）
+	Line int
+	// Column 字段所在列号（无法定位时为 0）
+	Column int
+	// Suggestion 根据编辑距离推荐的正确字段路径（没有合适候选时为空）
+	Suggestion string
+}
+
+// String 返回未知字段的可读描述
+func (k UnknownKey) String() string {
+	location := k.File
+	if k.Line > 0 {
+		location = fmt.Sprintf("%s:%d:%d", k.File, k.Line, k.Column)
+	}
+	msg := fmt.Sprintf("%s: 未知字段 %q", location, k.Path)
+	if k.Suggestion != "" {
+		msg += fmt.Sprintf("，是否想使用 %q？", k.Suggestion)
+	}
+	return msg
+}
+
+// StrictError 严格模式校验失败错误
+// 包含配置文件中全部未知字段
+type StrictError struct {
+	Keys []UnknownKey
+}
+
+// Error 实现 error 接口
+func (e *StrictError) Error() string {
+	lines := make([]string, 0, len(e.Keys)+1)
+	lines = append(lines, fmt.Sprintf("%v（共 %d 个）:", ErrUnknownConfigKey, len(e.Keys)))
+	for _, k := range e.Keys {
+		lines = append(lines, "  "+k.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap 支持 errors.Is(err, ErrUnknownConfigKey)
+func (e *StrictError) Unwrap() error {
+	return ErrUnknownConfigKey
+}
+
+// checkUnknownKeys 检查配置中无法映射到 T 的字段
+// 参数：
+//
+//	settings: Viper 读取到的配置
+//	allow: 允许出现的自由字段（支持通配符）
+//
+// 返回值：
+//
+//	error: 存在未知字段时返回 *StrictError
+func (m *Manager[T]) checkUnknownKeys(settings map[string]any, allow []string) error {
+	var unknown []unknownPath
+	collectUnknownKeys(settings, reflect.TypeOf((*T)(nil)).Elem(), "", allow, &unknown)
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	file := m.vp.ConfigFileUsed()
+	var root *yaml.Node
+	if data, err := os.ReadFile(file); err == nil {
+		var doc yaml.Node
+		if yaml.Unmarshal(data, &doc) == nil {
+			root = &doc
+		}
+	}
+
+	keys := make([]UnknownKey, 0, len(unknown))
+	for _, u := range unknown {
+		key := UnknownKey{Path: u.path, File: file}
+		if node := findYAMLNode(root, u.path); node != nil {
+			key.Line, key.Column = node.Line, node.Column
+		}
+		if s := suggestKey(u.name, u.candidates); s != "" {
+			key.Suggestion = joinKeyPath(u.parent, s)
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Line != keys[j].Line {
+			return keys[i].Line < keys[j].Line
+		}
+		return keys[i].Path < keys[j].Path
+	})
+	return &StrictError{Keys: keys}
+}
+
+// unknownPath 未知字段的收集结果
+type unknownPath struct {
+	path       string
+	parent     string
+	name       string
+	candidates []string
+}
+
+// collectUnknownKeys 递归比对配置与结构体类型，收集未知字段
+func collectUnknownKeys(value any, t reflect.Type, prefix string, allow []string, out *[]unknownPath) {
+	t = indirectType(t)
+
+	switch t.Kind() {
+	case reflect.Struct:
+		m, ok := value.(map[string]any)
+		if !ok {
+			return
+		}
+		fields := structKeyFields(t)
+		if _, ok := fields[remainFieldKey]; ok {
+			// 存在 ",remain" 字段，所有剩余字段都是合法的
+			return
+		}
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for k, v := range m {
+			p := joinKeyPath(prefix, k)
+			if keyAllowed(p, allow) {
+				continue
+			}
+			field, ok := fields[strings.ToLower(k)]
+			if !ok {
+				*out = append(*out, unknownPath{path: p, parent: prefix, name: k, candidates: names})
+				continue
+			}
+			collectUnknownKeys(v, field.Type, p, allow, out)
+		}
+	case reflect.Map:
+		m, ok := value.(map[string]any)
+		if !ok {
+			return
+		}
+		for k, v := range m {
+			collectUnknownKeys(v, t.Elem(), joinKeyPath(prefix, k), allow, out)
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]any)
+		if !ok {
+			return
+		}
+		for i, v := range items {
+			collectUnknownKeys(v, t.Elem(), prefix+"["+strconv.Itoa(i)+"]", allow, out)
+		}
+	}
+}
+
+// remainFieldKey 标记 ",remain" 字段的内部键
+const remainFieldKey = "\x00remain"
+
+// structKeyFields 返回结构体可映射的字段（键为小写的 mapstructure 名称）
+// 会展开匿名嵌入字段和 ",squash" 字段
+func structKeyFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+		if name == "-" {
+			continue
+		}
+		if strings.Contains(opts, "remain") {
+			fields[remainFieldKey] = f
+			continue
+		}
+		if strings.Contains(opts, "squash") || (f.Anonymous && name == "") {
+			if ft := indirectType(f.Type); ft.Kind() == reflect.Struct {
+				for k, v := range structKeyFields(ft) {
+					fields[k] = v
+				}
+				continue
+			}
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f
+	}
+	return fields
+}
+
+// pruneUnknownKeys 移除无法映射到结构体的字段
+// 在未知字段检查通过后调用，此时剩余的未知字段均来自白名单，
+// 移除它们可以避免 ErrorUnused 误报
+func pruneUnknownKeys(value any, t reflect.Type) any {
+	t = indirectType(t)
+
+	switch t.Kind() {
+	case reflect.Struct:
+		m, ok := value.(map[string]any)
+		if !ok {
+			return value
+		}
+		fields := structKeyFields(t)
+		if _, ok := fields[remainFieldKey]; ok {
+			return value
+		}
+		out := make(map[string]any, len(m))
+		for k, v := range m {
+			if field, ok := fields[strings.ToLower(k)]; ok {
+				out[k] = pruneUnknownKeys(v, field.Type)
+			}
+		}
+		return out
+	case reflect.Map:
+		m, ok := value.(map[string]any)
+		if !ok {
+			return value
+		}
+		out := make(map[string]any, len(m))
+		for k, v := range m {
+			out[k] = pruneUnknownKeys(v, t.Elem())
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]any)
+		if !ok {
+			return value
+		}
+		out := make([]any, len(items))
+		for i, v := range items {
+			out[i] = pruneUnknownKeys(v, t.Elem())
+		}
+		return out
+	}
+	return value
+}
+
+// keyAllowed 判断字段路径是否在白名单中
+// 白名单条目匹配自身及其所有子字段，并支持 path.Match 通配符
+func keyAllowed(p string, allow []string) bool {
+	for _, pattern := range allow {
+		pattern = strings.ToLower(pattern)
+		if p == pattern || strings.HasPrefix(p, pattern+".") || strings.HasPrefix(p, pattern+"[") {
+			return true
+		}
+		if ok, _ := path.Match(pattern, p); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// suggestKey 根据编辑距离从候选字段中选出最接近的一个
+func suggestKey(name string, candidates []string) string {
+	name = strings.ToLower(name)
+	best, bestDist := "", -1
+	for _, c := range candidates {
+		if c == remainFieldKey {
+			continue
+		}
+		d := levenshtein(name, c)
+		if bestDist == -1 || d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	// 距离过大的候选没有参考价值
+	limit := max(2, len(name)/3)
+	if bestDist == -1 || bestDist > limit {
+		return ""
+	}
+	return best
+}
+
+// levenshtein 计算两个字符串的编辑距离
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
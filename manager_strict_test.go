@@ -0,0 +1,86 @@
+package configx
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestManager 在临时目录中写入配置文件并创建管理器
+func newTestManager[T any](t *testing.T, content string, opts *Option) *Manager[T] {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(content), 0600); err != nil {
+		t.Fatalf("写入配置文件失败: %v", err)
+	}
+	if opts == nil {
+		opts = NewOption()
+	}
+	opts.Filepath.Set(OptionString(dir))
+	opts.Filename.Set("config.yaml")
+
+	var zero T
+	return NewManager(zero).SetOption(opts)
+}
+
+type strictTestConfig struct {
+	Database struct {
+		Host         string `mapstructure:"host"`
+		MaxOpenConns int    `mapstructure:"max_open_conns"`
+	} `mapstructure:"database"`
+	Plugins map[string]any `mapstructure:"plugins"`
+}
+
+// TestStrictRejectsUnknownKeys 测试严格模式拒绝拼写错误的字段并给出建议
+func TestStrictRejectsUnknownKeys(t *testing.T) {
+	opts := NewOption()
+	opts.Strict = true
+	manager := newTestManager[strictTestConfig](t, "database:\n  host: db\n  max_open_con: 5\nextra: 1\n", opts)
+
+	err := manager.LoadConfig()
+	if !errors.Is(err, ErrUnknownConfigKey) {
+		t.Fatalf("期望 ErrUnknownConfigKey，实际: %v", err)
+	}
+
+	var strictErr *StrictError
+	if !errors.As(err, &strictErr) {
+		t.Fatalf("期望 *StrictError，实际: %T", err)
+	}
+	if len(strictErr.Keys) != 2 {
+		t.Fatalf("期望 2 个未知字段，实际: %v", strictErr.Keys)
+	}
+
+	typo := strictErr.Keys[0]
+	if typo.Path != "database.max_open_con" || typo.Line != 3 {
+		t.Errorf("未知字段定位错误: %+v", typo)
+	}
+	if typo.Suggestion != "database.max_open_conns" {
+		t.Errorf("建议字段错误: %q", typo.Suggestion)
+	}
+}
+
+// TestStrictAllowKeys 测试白名单字段不会触发严格模式错误
+func TestStrictAllowKeys(t *testing.T) {
+	opts := NewOption()
+	opts.Strict = true
+	opts.StrictAllowKeys = []string{"x-*"}
+	manager := newTestManager[strictTestConfig](t, "database:\n  host: db\nplugins:\n  any: thing\nx-anchor: 1\n", opts)
+
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	cfg, _ := manager.GetConfig()
+	if cfg.Database.Host != "db" || cfg.Plugins["any"] != "thing" {
+		t.Errorf("配置解析错误: %+v", cfg)
+	}
+}
+
+// TestNonStrictIgnoresUnknownKeys 测试默认模式保持兼容，忽略未知字段
+func TestNonStrictIgnoresUnknownKeys(t *testing.T) {
+	manager := newTestManager[strictTestConfig](t, "database:\n  max_open_con: 5\n", nil)
+
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+}
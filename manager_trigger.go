@@ -0,0 +1,168 @@
+package configx
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	// TriggerSignal 收到 Option.ReloadSignals 中的信号
+	TriggerSignal ReloadTrigger = "signal"
+	// TriggerTouchFile Option.ReloadTouchFile 指定的控制文件发生变化
+	TriggerTouchFile ReloadTrigger = "touch_file"
+)
+
+// Close 关闭管理器
+// 停止信号与控制文件触发器、定时重载以及 OnChange 回调的执行队列，之后的文件变更不再触发重载；
+// 已加载的配置仍可通过 GetConfig 读取。可以重复调用
+// 返回值：
+//
+//	error: 始终为 nil（保留用于实现 io.Closer）
+func (m *Manager[T]) Close() error {
+	m.closeOnce.Do(func() {
+		close(m.closed)
+
+		m.handlerMutex.Lock()
+		handlers := m.changeHandlers
+		m.changeHandlers = nil
+		m.handlerMutex.Unlock()
+		for _, h := range handlers {
+			h.stop()
+		}
+	})
+	return nil
+}
+
+// isClosed 判断管理器是否已关闭
+func (m *Manager[T]) isClosed() bool {
+	select {
+	case <-m.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// debounce 防抖：距上次触发不足 debounceDur 时返回 false
+// 窗口内的触发不会被丢弃：窗口结束时执行一次 trailing（窗口内的多次触发合并为一次，
+// 执行最后一次触发传入的 trailing，事件与钩子中的触发来源为最后一次触发），保证窗口内最后一次修改最终生效
+// 文件监听、信号与控制文件触发器共享同一防抖窗口
+func (m *Manager[T]) debounce(trailing func()) bool {
+	now := time.Now().UnixNano()
+	last := m.lastChangeNano.Load()
+	wait := m.debounceDur - time.Duration(now-last)
+	if wait <= 0 {
+		if m.lastChangeNano.CompareAndSwap(last, now) {
+			return true
+		}
+		// 与其他触发同时到达，等待一个完整的窗口
+		wait = m.debounceDur
+	}
+	m.trailingFunc.Store(&trailing)
+	if m.trailingPending.CompareAndSwap(false, true) {
+		time.AfterFunc(wait, func() {
+			m.trailingPending.Store(false)
+			if m.isClosed() {
+				return
+			}
+			m.lastChangeNano.Store(time.Now().UnixNano())
+			(*m.trailingFunc.Load())()
+		})
+	}
+	return false
+}
+
+// startTriggers 启动 Option 中配置的重载触发器
+func (m *Manager[T]) startTriggers(opts *Option) error {
+	if len(opts.ReloadSignals) > 0 {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, opts.ReloadSignals...)
+		go func() {
+			defer signal.Stop(signals)
+			for {
+				select {
+				case <-m.closed:
+					return
+				case sig := <-signals:
+					m.triggerReload(TriggerSignal, slog.String("signal", sig.String()))
+				}
+			}
+		}()
+	}
+
+	if opts.ReloadTouchFile != "" {
+		touchFile := opts.ReloadTouchFile
+		if !filepath.IsAbs(touchFile) {
+			touchFile = filepath.Join(opts.Path(), touchFile)
+		}
+		touchFile = filepathAbs(touchFile)
+
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			return fmt.Errorf("创建控制文件监听失败: %w", err)
+		}
+		// 监听所在目录，控制文件可以在启动后才创建
+		if err := watcher.Add(filepath.Dir(touchFile)); err != nil {
+			watcher.Close()
+			return fmt.Errorf("监听控制文件 %s 失败: %w", touchFile, err)
+		}
+		go func() {
+			defer watcher.Close()
+			for {
+				select {
+				case <-m.closed:
+					return
+				case e, ok := <-watcher.Events:
+					if !ok {
+						return
+					}
+					if filepathAbs(e.Name) != touchFile || !e.Has(fsnotify.Create|fsnotify.Write|fsnotify.Chmod) {
+						continue
+					}
+					m.triggerReload(TriggerTouchFile, slog.String("touch_file", touchFile))
+				case err, ok := <-watcher.Errors:
+					if !ok {
+						return
+					}
+					m.executeHook(Error, HookContext{
+						Message: fmt.Sprintf("[config] 控制文件监听错误: %v", err),
+						Event:   EventReloadFailed,
+						File:    touchFile,
+						Err:     err,
+					})
+				}
+			}
+		}()
+	}
+	return nil
+}
+
+// triggerReload 由触发器发起一次重载（经过防抖）
+func (m *Manager[T]) triggerReload(trigger ReloadTrigger, attrs ...slog.Attr) {
+	if m.isClosed() {
+		return
+	}
+	run := func() { m.runTrigger(trigger, attrs...) }
+	if m.debounce(run) {
+		run()
+	}
+}
+
+// runTrigger 执行触发器发起的重载
+func (m *Manager[T]) runTrigger(trigger ReloadTrigger, attrs ...slog.Attr) {
+	file := m.vp.ConfigFileUsed()
+	m.executeHook(Info, HookContext{
+		Message: fmt.Sprintf("[config] 收到重载请求（%s）", trigger),
+		Event:   EventReloadTriggered,
+		File:    file,
+		Attrs:   append([]slog.Attr{slog.String("trigger", string(trigger))}, attrs...),
+	})
+	m.reload(context.Background(), trigger, fsnotify.Event{Name: file, Op: fsnotify.Write})
+}
@@ -0,0 +1,153 @@
+//go:build unix
+
+package configx
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// waitReload 等待一次 reload_ok 事件并返回触发来源
+func waitReload(t *testing.T, events <-chan HookContext) string {
+	t.Helper()
+	select {
+	case ctx := <-events:
+		for _, attr := range ctx.Attrs {
+			if attr.Key == "trigger" {
+				return attr.Value.String()
+			}
+		}
+		return ""
+	case <-time.After(2 * time.Second):
+		t.Fatal("等待重载超时")
+		return ""
+	}
+}
+
+// TestReloadTriggers 测试信号与控制文件触发重载，Close 后停止
+func TestReloadTriggers(t *testing.T) {
+	opts := NewOption()
+	opts.DisableWatch = true
+	opts.DebounceDur.Set(OptionTimeDuration(time.Millisecond))
+	opts.ReloadSignals = []os.Signal{syscall.SIGHUP}
+	opts.ReloadTouchFile = "config.reload"
+	manager := newTestManager[reloadTestConfig](t, "dsn: v1\n", opts)
+
+	events := make(chan HookContext, 10)
+	manager.AddHook(Info, func(ctx HookContext) {
+		if ctx.Event == EventReloadOK {
+			events <- ctx
+		}
+	})
+	if err := manager.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer manager.Close()
+
+	writeConfig := func(content string) {
+		if err := os.WriteFile(opts.File(), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeConfig("dsn: v2\n")
+	if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatal(err)
+	}
+	if trigger := waitReload(t, events); trigger != string(TriggerSignal) {
+		t.Errorf("触发来源错误: %q", trigger)
+	}
+	if cfg, _ := manager.GetConfig(); cfg.DSN != "v2" {
+		t.Errorf("信号触发的重载未生效: %s", cfg.DSN)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	writeConfig("dsn: v3\n")
+	if err := os.WriteFile(filepath.Join(opts.Path(), "config.reload"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if trigger := waitReload(t, events); trigger != string(TriggerTouchFile) {
+		t.Errorf("触发来源错误: %q", trigger)
+	}
+	if cfg, _ := manager.GetConfig(); cfg.DSN != "v3" {
+		t.Errorf("控制文件触发的重载未生效: %s", cfg.DSN)
+	}
+
+	manager.Close()
+	time.Sleep(5 * time.Millisecond)
+	writeConfig("dsn: v4\n")
+	manager.triggerReload(TriggerSignal)
+	if cfg, _ := manager.GetConfig(); cfg.DSN != "v3" {
+		t.Errorf("Close 后不应再重载: %s", cfg.DSN)
+	}
+}
+
+// TestDebounceTrailing 测试防抖窗口内的触发合并为窗口结束时的一次重载
+func TestDebounceTrailing(t *testing.T) {
+	manager := NewManager(reloadTestConfig{})
+	manager.debounceDur = 50 * time.Millisecond
+
+	trailing := make(chan struct{}, 4)
+	run := func() { trailing <- struct{}{} }
+	if !manager.debounce(run) {
+		t.Fatal("窗口外的首次触发应立即执行")
+	}
+	if manager.debounce(run) || manager.debounce(run) {
+		t.Fatal("窗口内的触发不应立即执行")
+	}
+
+	select {
+	case <-trailing:
+	case <-time.After(time.Second):
+		t.Fatal("窗口结束时应补发一次重载")
+	}
+	select {
+	case <-trailing:
+		t.Error("窗口内的多次触发应只补发一次")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// TestDebounceTrailingLatest 测试窗口结束时补发的重载使用最后一次触发
+func TestDebounceTrailingLatest(t *testing.T) {
+	manager := NewManager(reloadTestConfig{})
+	manager.debounceDur = 50 * time.Millisecond
+
+	triggers := make(chan ReloadTrigger, 4)
+	run := func(trigger ReloadTrigger) func() {
+		return func() { triggers <- trigger }
+	}
+	if !manager.debounce(run(TriggerWatch)) {
+		t.Fatal("窗口外的首次触发应立即执行")
+	}
+	manager.debounce(run(TriggerSignal))
+	manager.debounce(run(TriggerTouchFile))
+
+	select {
+	case got := <-triggers:
+		if got != TriggerTouchFile {
+			t.Errorf("补发的重载应使用最后一次触发，实际: %s", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("窗口结束时应补发一次重载")
+	}
+}
+
+// TestOnChangeAfterClose 测试关闭后注册的回调不会启动执行队列
+func TestOnChangeAfterClose(t *testing.T) {
+	manager := NewManager(reloadTestConfig{})
+	manager.Close()
+
+	handle := manager.OnChange(func(ctx *Context) { t.Error("关闭后不应执行回调") })
+	if handle == nil {
+		t.Fatal("应返回可调用 Remove 的句柄")
+	}
+	handle.Remove()
+	if len(manager.changeHandlers) != 0 {
+		t.Errorf("关闭后不应注册回调: %d", len(manager.changeHandlers))
+	}
+	manager.dispatchChange(testChangeContext(manager))
+}
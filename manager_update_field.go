@@ -1,10 +1,14 @@
 package configx
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"reflect"
 	"strings"
+
+	"github.com/fsnotify/fsnotify"
+	"gopkg.in/yaml.v3"
 )
 
 // UpdateField is deprecated - use Manager.UpdateField instead
@@ -22,31 +26,184 @@ import (
 // 返回值：
 //
 //	error: 更新过程中的错误
+//
+// 注意：
+//   - 修改先在配置的深拷贝上进行（实现 Cloneable 接口时使用 Clone），写回文件成功后才生效；配置文件已整体加密（Option.FileDecrypter）、需要签名（Option.SignatureKey）或写回失败时返回错误，配置保持不变
+//   - 开启 Option.Interpolate 时，原始值为插值模板（如 "${DB_HOST}"）的字段不会被写回文件，
+//     文件中保留模板文本，并触发 Warn 钩子提示
+//   - 原始值为 ENC[...] 密文的字段，写回时使用 Option.KeyProvider 重新加密
+//   - 配置发生变化时与热重载一样通知 Init、OnChange 注册的回调与 Watch 订阅者（Trigger 为 update）；
+//     同步回调在 UpdateField 返回前执行完毕，在回调中调用 UpdateField 时新的变更在当前事件分发完成后分发。
+//     Set 与 Section.Update 相同
 func (m *Manager[T]) UpdateField(updateFunc func(*T)) error {
+	warnings, err := m.updateField(func(c *T) error {
+		updateFunc(c)
+		return nil
+	})
+	// 在锁外触发钩子，避免钩子中访问配置导致死锁
+	m.executeHooks(warnings)
+	return err
+}
+
+// TriggerUpdate 调用 UpdateField、Set 或 Section.Update
+const TriggerUpdate ReloadTrigger = "update"
+
+// updateField 在配置副本上执行更新，写回文件成功后才替换当前配置并递增版本号，
+// 并将变更（Trigger 为 update）分发给 OnChange 回调与 Watch 订阅者
+// updateFunc 返回错误或写回失败时配置保持不变
+// 与热重载、ApplyPatch 串行执行，避免重载基于旧配置替换时丢失本次修改
+// 返回值：
+//
+//	[]HookContext: 更新过程中产生的待触发钩子
+//	error: 更新过程中的错误
+func (m *Manager[T]) updateField(updateFunc func(*T) error) ([]HookContext, error) {
+	// 释放 reloadLock 后再分发变更
+	defer m.flushChanges()
+	m.reloadLock.Lock()
+	defer m.reloadLock.Unlock()
+
+	m.rwMutex.RLock()
+	if m.config == nil {
+		m.rwMutex.RUnlock()
+		return nil, ErrConfigNotInitialized
+	}
+	oldConfig := *m.config
+	// 深拷贝：updateFunc 修改映射、切片或指针指向的值时不影响运行中的配置
+	newConfig, err := cloneValue(oldConfig)
+	m.rwMutex.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := updateFunc(&newConfig); err != nil {
+		return nil, err
+	}
+	warnings, err := m.replaceConfig(oldConfig, &newConfig)
+	if err != nil {
+		return warnings, err
+	}
+	m.markApplied(&newConfig)
+
+	if keys := changedKeys(oldConfig, newConfig); len(keys) > 0 {
+		file := m.vp.ConfigFileUsed()
+		summary := ChangeSummary{Trigger: TriggerUpdate, File: file, ChangedKeys: keys, Version: m.Version()}
+		m.publishChange(TriggerUpdate, fsnotify.Event{Name: file, Op: fsnotify.Write}, oldConfig, summary)
+	}
+	return warnings, nil
+}
+
+// replaceConfig 在写锁保护下将 newConfig 写回配置文件并替换当前配置
+// 不递增版本号：调用方在修改被接受后调用 markApplied
+// 返回值：
+//
+//	[]HookContext: 写回过程中产生的待触发钩子
+//	error: 写回失败时返回错误，此时当前配置与文件均保持不变
+func (m *Manager[T]) replaceConfig(oldConfig T, newConfig *T) ([]HookContext, error) {
 	m.rwMutex.Lock()
 	defer m.rwMutex.Unlock()
 
-	oldConfig := *m.config
-	updateFunc(m.config)
+	warnings, err := m.writeBack(oldConfig, *newConfig)
+	if err != nil {
+		return warnings, err
+	}
+	m.config = newConfig
+	return warnings, nil
+}
 
+// writeBack 将 oldConfig 到 newConfig 的修改写回配置文件
+// 返回值：
+//
+//	[]HookContext: 写回过程中产生的待触发钩子
+//	error: 配置文件已整体加密或需要签名、重新加密失败（包括文件中找不到原密文）或写入失败时返回错误，此时文件保持不变
+func (m *Manager[T]) writeBack(oldConfig, newConfig T) ([]HookContext, error) {
 	configFile := m.vp.ConfigFileUsed()
+	if err := checkWritable(configFile, m.options()); err != nil {
+		return nil, err
+	}
 	content, err := os.ReadFile(configFile)
 	if err != nil {
-		return fmt.Errorf("failed to read config file: %w", err)
+		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
 	newContent := string(content)
 
-	var updateContent func(reflect.Value, reflect.Value, reflect.Type)
-	updateContent = func(oldVal, newVal reflect.Value, t reflect.Type) {
+	// 将弃用字段改写为新字段名，保证后续按新字段名匹配
+	if m.options().RewriteAliases && isYAMLFile(configFile) {
+		rewritten, _, err := m.rewriteAliasKeys(content)
+		if err != nil {
+			return nil, err
+		}
+		newContent = string(rewritten)
+	}
+
+	// 原始值为插值模板的字段
+	templated := make(map[string]bool)
+	if keys := m.templateKeys.Load(); keys != nil {
+		for _, key := range *keys {
+			templated[key] = true
+		}
+	}
+	// 启用插值时文件中的字面量 ${ 以 $${ 转义保存，按转义后的文本匹配与写回
+	escape := func(s string) string { return s }
+	if m.options().Interpolate {
+		escape = escapeInterpolation
+	}
+	// 原始值为密文的字段
+	encrypted := make(map[string]string)
+	if keys := m.encryptedKeys.Load(); keys != nil {
+		encrypted = *keys
+	}
+	// 本次重新加密的字段及其新密文
+	resealed := make(map[string]string)
+	var warnings []HookContext
+	var encryptErr error
+	// 无法按文本替换写回的字段（映射、结构体指针、块格式的切片），之后按 YAML 节点写回
+	var nodeUpdates []fieldUpdate
+
+	var updateContent func(reflect.Value, reflect.Value, reflect.Type, string)
+	updateContent = func(oldVal, newVal reflect.Value, t reflect.Type, prefix string) {
 		for i := 0; i < oldVal.NumField(); i++ {
 			oldField, newField := oldVal.Field(i), newVal.Field(i)
 			if tag := t.Field(i).Tag.Get("mapstructure"); tag != "" {
-				if oldField.Kind() == reflect.Struct {
-					updateContent(oldField, newField, oldField.Type())
+				name, _, _ := strings.Cut(tag, ",")
+				path := joinKeyPath(prefix, strings.ToLower(name))
+				if oldField.Kind() == reflect.Struct && !isLeafValue(oldField) {
+					updateContent(oldField, newField, oldField.Type(), path)
+				} else if templated[path] {
+					if !reflect.DeepEqual(oldField.Interface(), newField.Interface()) {
+						warnings = append(warnings, HookContext{
+							Message: fmt.Sprintf("[config] 字段 %s 的值来自插值模板，已保留文件中的模板文本，修改仅在内存中生效", path),
+							Pattern: Warn,
+							Event:   EventWriteSkipped,
+							Key:     path,
+						})
+					}
+				} else if raw, ok := encrypted[path]; ok {
+					if !reflect.DeepEqual(oldField.Interface(), newField.Interface()) {
+						sealed, err := m.encryptForWrite(formatValue(newField))
+						if err != nil {
+							encryptErr = errors.Join(encryptErr, fmt.Errorf("%s: %w", path, err))
+							continue
+						}
+						replaced := false
+						for _, pattern := range []string{fmt.Sprintf(`%s: "%s"`, tag, raw), fmt.Sprintf(`%s: %s`, tag, raw)} {
+							if strings.Contains(newContent, pattern) {
+								newContent = strings.ReplaceAll(newContent, pattern, fmt.Sprintf(`%s: "%s"`, tag, sealed))
+								replaced = true
+								break
+							}
+						}
+						if !replaced {
+							encryptErr = errors.Join(encryptErr, fmt.Errorf("%s: 配置文件中未找到原密文", path))
+							continue
+						}
+						resealed[path] = sealed
+					}
 				} else if !reflect.DeepEqual(oldField.Interface(), newField.Interface()) {
 					var old, new string
-					if oldField.Kind() == reflect.Slice || oldField.Kind() == reflect.Array {
+					if oldField.Kind() == reflect.Map || (oldField.Kind() == reflect.Pointer && !isLeafValue(oldField)) {
+						nodeUpdates = append(nodeUpdates, fieldUpdate{path: path, value: newField})
+					} else if oldField.Kind() == reflect.Slice || oldField.Kind() == reflect.Array {
 						// 数组类型
 						var oldElems, newElems []string
 						for i := 0; i < oldField.Len(); i++ {
@@ -54,7 +211,7 @@ func (m *Manager[T]) UpdateField(updateFunc func(*T)) error {
 							if elem.Kind() == reflect.String {
 								oldElems = append(oldElems, fmt.Sprintf(`"%s"`, elem.String()))
 							} else {
-								oldElems = append(oldElems, fmt.Sprintf("%v", elem.Interface()))
+								oldElems = append(oldElems, formatValue(elem))
 							}
 						}
 						for i := 0; i < newField.Len(); i++ {
@@ -62,27 +219,32 @@ func (m *Manager[T]) UpdateField(updateFunc func(*T)) error {
 							if elem.Kind() == reflect.String {
 								newElems = append(newElems, fmt.Sprintf(`"%s"`, elem.String()))
 							} else {
-								newElems = append(newElems, fmt.Sprintf("%v", elem.Interface()))
+								newElems = append(newElems, formatValue(elem))
 							}
 						}
 						old, new = fmt.Sprintf("[%s]", strings.Join(oldElems, ", ")), fmt.Sprintf("[%s]", strings.Join(newElems, ", "))
 
+						replaced := false
 						for _, pattern := range []string{fmt.Sprintf(`%s: %s`, tag, old), fmt.Sprintf(`%s: []`, tag)} {
 							if strings.Contains(newContent, pattern) {
 								newContent = strings.ReplaceAll(newContent, pattern, fmt.Sprintf(`%s: %s`, tag, new))
+								replaced = true
 								break
 							}
 						}
+						if !replaced {
+							nodeUpdates = append(nodeUpdates, fieldUpdate{path: path, value: newField})
+						}
 					} else {
-						// 非数组类型
-						old, new = fmt.Sprintf("%v", oldField.Interface()), fmt.Sprintf("%v", newField.Interface())
+						// 非数组类型（使用可读形式，如 Duration 写回 "30s"）
+						old, new = escape(formatValue(oldField)), escape(formatScalar(newField))
 						for _, pattern := range []string{
 							fmt.Sprintf(`%s: "%s"`, tag, old),
 							fmt.Sprintf(`%s: %s`, tag, old),
 							fmt.Sprintf(`%s: ""`, tag),
 						} {
 							if strings.Contains(newContent, pattern) {
-								newContent = strings.ReplaceAll(newContent, pattern, fmt.Sprintf(`%s: "%s"`, tag, new))
+								newContent = strings.ReplaceAll(newContent, pattern, fmt.Sprintf(`%s: %s`, tag, new))
 								break
 							}
 						}
@@ -92,11 +254,121 @@ func (m *Manager[T]) UpdateField(updateFunc func(*T)) error {
 		}
 	}
 
-	updateContent(reflect.ValueOf(oldConfig), reflect.ValueOf(*m.config), reflect.TypeOf(oldConfig))
+	updateContent(reflect.ValueOf(oldConfig), reflect.ValueOf(newConfig), reflect.TypeOf(oldConfig), "")
+	if encryptErr != nil {
+		// 加密失败时不写入文件，避免明文落盘
+		return warnings, fmt.Errorf("重新加密配置值失败: %w", encryptErr)
+	}
+
+	if len(nodeUpdates) > 0 {
+		if !isYAMLFile(configFile) {
+			for _, update := range nodeUpdates {
+				warnings = append(warnings, HookContext{
+					Message: fmt.Sprintf("[config] 字段 %s 只能写回 YAML 配置文件，修改仅在内存中生效", update.path),
+					Pattern: Warn,
+					Event:   EventWriteSkipped,
+					Key:     update.path,
+				})
+			}
+		} else {
+			updated, err := writeYAMLFields([]byte(newContent), nodeUpdates)
+			if err != nil {
+				return warnings, fmt.Errorf("写回配置文件失败: %w", err)
+			}
+			newContent = string(updated)
+		}
+	}
 
 	if newContent != string(content) {
-		return os.WriteFile(configFile, []byte(newContent), 0644)
+		// 先记录写入内容的哈希，文件监听收到本次写入时跳过重载
+		previous := m.writtenHash.Load()
+		hash := contentHash([]byte(newContent))
+		m.writtenHash.Store(&hash)
+		if err := os.WriteFile(configFile, []byte(newContent), 0644); err != nil {
+			m.writtenHash.Store(previous)
+			return warnings, fmt.Errorf("写回配置文件失败: %w", err)
+		}
+		m.storeAppliedHash(hash)
+	}
+	if len(resealed) > 0 {
+		// 记录文件中的新密文，后续写回按新密文匹配
+		keys := make(map[string]string, len(encrypted))
+		for path, raw := range encrypted {
+			keys[path] = raw
+		}
+		for path, sealed := range resealed {
+			keys[path] = sealed
+		}
+		m.encryptedKeys.Store(&keys)
+	}
+
+	return warnings, nil
+}
+
+// selfWritten 判断配置文件的当前内容是否为 UpdateField、Set、ApplyPatch 最近一次写回的内容
+// 内容不同（文件已被外部修改）时清除记录，之后的变更都按外部修改处理
+func (m *Manager[T]) selfWritten(file string) bool {
+	written := m.writtenHash.Load()
+	if written == nil {
+		return false
+	}
+	if hash, err := fileHash(file); err == nil && hash == *written {
+		return true
+	}
+	m.writtenHash.CompareAndSwap(written, nil)
+	return false
+}
+
+// encryptForWrite 使用 Option.KeyProvider 加密待写回的配置值
+func (m *Manager[T]) encryptForWrite(plaintext string) (string, error) {
+	provider := m.options().KeyProvider
+	if provider == nil {
+		return "", errors.New("未设置 Option.KeyProvider")
+	}
+	key, err := provider.Key()
+	if err != nil {
+		return "", err
+	}
+	return EncryptValue(plaintext, key)
+}
+
+// fieldUpdate 待按 YAML 节点写回的字段
+type fieldUpdate struct {
+	path  string
+	value reflect.Value
+}
+
+// writeYAMLFields 将字段的新值写入 YAML 文档（保留注释），路径不存在时插入
+func writeYAMLFields(content []byte, updates []fieldUpdate) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	if yamlRoot(&doc) == nil {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	if err := setYAMLFields(&doc, updates); err != nil {
+		return nil, err
 	}
+	return encodeYAML(&doc)
+}
 
+// setYAMLFields 在 YAML 文档节点中写入字段的新值，保留原值节点上的注释
+func setYAMLFields(doc *yaml.Node, updates []fieldUpdate) error {
+	for _, update := range updates {
+		var value yaml.Node
+		if err := value.Encode(plainValue(update.value)); err != nil {
+			return fmt.Errorf("%s: %w", update.path, err)
+		}
+		if existing := yamlValueOf(doc, update.path); existing != nil {
+			value.HeadComment, value.LineComment, value.FootComment = existing.HeadComment, existing.LineComment, existing.FootComment
+			*existing = value
+			continue
+		}
+		key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str"}
+		if !insertYAMLKey(doc, update.path, key, &value) {
+			return fmt.Errorf("%s: 无法写入配置文件", update.path)
+		}
+	}
 	return nil
 }
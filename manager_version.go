@@ -0,0 +1,170 @@
+package configx
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"reflect"
+	"sort"
+)
+
+// Version 返回当前配置的版本号
+// 每次应用新配置（加载、热重载、UpdateField）时单调递增，未加载时为 0
+func (m *Manager[T]) Version() uint64 {
+	return m.version.Load()
+}
+
+// ETag 返回当前配置内容的哈希（十六进制 SHA-256），未加载时为空字符串
+// 哈希基于解析后的配置值（包括 Secret 的真实值），内容相同的配置具有相同的 ETag
+func (m *Manager[T]) ETag() string {
+	if etag := m.etag.Load(); etag != nil {
+		return *etag
+	}
+	return ""
+}
+
+// WaitForVersion 阻塞直到配置版本号不小于 version
+// 返回值：
+//
+//	error: ctx 取消或超时时返回 ctx.Err()
+//
+// 示例：
+//
+//	v := manager.Version()
+//	os.WriteFile(file, newContent, 0600)
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	err := manager.WaitForVersion(ctx, v+1)
+func (m *Manager[T]) WaitForVersion(ctx context.Context, version uint64) error {
+	for {
+		changed := m.versionChanged()
+		if m.Version() >= version {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// WaitForChange 阻塞直到应用了新的配置
+// 返回值：
+//
+//	uint64: 新的配置版本号
+//	error: ctx 取消或超时时返回 ctx.Err()
+func (m *Manager[T]) WaitForChange(ctx context.Context) (uint64, error) {
+	next := m.Version() + 1
+	if err := m.WaitForVersion(ctx, next); err != nil {
+		return m.Version(), err
+	}
+	return m.Version(), nil
+}
+
+// versionChanged 返回在下一次版本变化时关闭的通道
+func (m *Manager[T]) versionChanged() <-chan struct{} {
+	m.versionMutex.Lock()
+	defer m.versionMutex.Unlock()
+	if m.versionCh == nil {
+		m.versionCh = make(chan struct{})
+	}
+	return m.versionCh
+}
+
+// markApplied 记录新应用的配置：更新 ETag、递增版本号并唤醒等待者
+func (m *Manager[T]) markApplied(cfg *T) {
+	etag := configHash(*cfg)
+	m.refreshBindings(cfg)
+	m.recordInterval(cfg)
+	m.resumeScheduler()
+
+	m.versionMutex.Lock()
+	defer m.versionMutex.Unlock()
+	m.etag.Store(&etag)
+	m.version.Add(1)
+	if m.versionCh != nil {
+		close(m.versionCh)
+		m.versionCh = nil
+	}
+}
+
+// configHash 计算配置值的哈希
+// 不使用 JSON 等序列化方式，避免 Secret 脱敏导致不同密码得到相同的哈希
+func configHash(v any) string {
+	h := sha256.New()
+	hashValue(h, reflect.ValueOf(v), make(map[uintptr]bool))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// hashValue 将值以确定的顺序写入哈希（映射按键排序，指针环只访问一次）
+func hashValue(h hash.Hash, v reflect.Value, visited map[uintptr]bool) {
+	if !v.IsValid() {
+		h.Write([]byte("<nil>;"))
+		return
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			h.Write([]byte("<nil>;"))
+			return
+		}
+		if visited[v.Pointer()] {
+			h.Write([]byte("<cycle>;"))
+			return
+		}
+		visited[v.Pointer()] = true
+		hashValue(h, v.Elem(), visited)
+	case reflect.Interface:
+		hashValue(h, v.Elem(), visited)
+	case reflect.Struct:
+		h.Write([]byte("{"))
+		for i := 0; i < v.NumField(); i++ {
+			hashValue(h, v.Field(i), visited)
+		}
+		h.Write([]byte("}"))
+	case reflect.Slice, reflect.Array:
+		fmt.Fprintf(h, "[%d:", v.Len())
+		for i := 0; i < v.Len(); i++ {
+			hashValue(h, v.Index(i), visited)
+		}
+		h.Write([]byte("]"))
+	case reflect.Map:
+		keys := v.MapKeys()
+		encoded := make([]string, len(keys))
+		for i, key := range keys {
+			kh := sha256.New()
+			hashValue(kh, key, visited)
+			encoded[i] = string(kh.Sum(nil))
+		}
+		order := make([]int, len(keys))
+		for i := range order {
+			order[i] = i
+		}
+		sort.Slice(order, func(a, b int) bool { return encoded[order[a]] < encoded[order[b]] })
+		fmt.Fprintf(h, "map[%d:", v.Len())
+		for _, i := range order {
+			h.Write([]byte(encoded[i]))
+			hashValue(h, v.MapIndex(keys[i]), visited)
+		}
+		h.Write([]byte("]"))
+	case reflect.String:
+		// reflect.Value.String 返回原始字符串，不会调用 Secret.String
+		fmt.Fprintf(h, "%q;", v.String())
+	case reflect.Bool:
+		fmt.Fprintf(h, "%t;", v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fmt.Fprintf(h, "%d;", v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		fmt.Fprintf(h, "%d;", v.Uint())
+	case reflect.Float32, reflect.Float64:
+		fmt.Fprintf(h, "%g;", v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		fmt.Fprintf(h, "%g;", v.Complex())
+	default:
+		// 函数、通道等不参与哈希
+		fmt.Fprintf(h, "<%s>;", v.Kind())
+	}
+}
@@ -0,0 +1,178 @@
+package configx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+type versionTestConfig struct {
+	Name     string `mapstructure:"name"`
+	Password Secret `mapstructure:"password"`
+}
+
+// TestVersionAndETag 测试版本号递增与 ETag 随内容变化
+func TestVersionAndETag(t *testing.T) {
+	manager := newTestManager[versionTestConfig](t, "name: a\npassword: one\n", nil)
+	if manager.Version() != 0 || manager.ETag() != "" {
+		t.Fatal("未加载时版本号应为 0")
+	}
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	v1, etag1 := manager.Version(), manager.ETag()
+	if v1 != 1 || etag1 == "" {
+		t.Fatalf("加载后版本号错误: %d %q", v1, etag1)
+	}
+
+	// 内容不变时 ETag 不变，版本号仍递增
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if manager.Version() != 2 || manager.ETag() != etag1 {
+		t.Errorf("相同内容应得到相同 ETag: %d %q", manager.Version(), manager.ETag())
+	}
+
+	// 只修改 Secret 时 ETag 也应变化
+	if err := reloadTestFile(t, manager, "name: a\npassword: two\n"); err != nil {
+		t.Fatal(err)
+	}
+	if manager.ETag() == etag1 {
+		t.Error("Secret 变化时 ETag 应变化")
+	}
+}
+
+// TestWaitForVersion 测试等待配置版本
+func TestWaitForVersion(t *testing.T) {
+	opts := NewOption()
+	opts.DisableWatch = true
+	manager := newTestManager[versionTestConfig](t, "name: a\n", opts)
+	if err := manager.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := manager.WaitForChange(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("期望超时，实际: %v", err)
+	}
+
+	target := manager.Version() + 1
+	done := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		done <- manager.WaitForVersion(ctx, target)
+	}()
+
+	if err := os.WriteFile(opts.File(), []byte("name: b\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	summary, err := manager.Reload(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("WaitForVersion 失败: %v", err)
+	}
+	if summary.Version != target {
+		t.Errorf("ChangeSummary.Version = %d, 期望 %d", summary.Version, target)
+	}
+}
+
+// TestFailedWriteKeepsVersion 测试写回失败时配置与版本号保持不变
+func TestFailedWriteKeepsVersion(t *testing.T) {
+	manager := newTestManager[versionTestConfig](t, "name: a\n", nil)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	name := Bind(manager, func(c *versionTestConfig) string { return c.Name })
+	defer name.Close()
+	changes := make(chan [2]string, 4)
+	name.OnChange(func(oldValue, newValue string) { changes <- [2]string{oldValue, newValue} })
+
+	version, etag := manager.Version(), manager.ETag()
+	if err := os.Remove(manager.vp.ConfigFileUsed()); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.UpdateField(func(c *versionTestConfig) { c.Name = "b" }); err == nil {
+		t.Fatal("配置文件不存在时写回应失败")
+	}
+	if manager.Version() != version || manager.ETag() != etag {
+		t.Errorf("写回失败后版本号不应变化: %d %q", manager.Version(), manager.ETag())
+	}
+	if cfg, _ := manager.GetConfig(); cfg.Name != "a" {
+		t.Errorf("写回失败后配置不应变化: %q", cfg.Name)
+	}
+	select {
+	case got := <-changes:
+		t.Errorf("写回失败时不应触发 OnChange: %v", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// TestUpdateFieldReferenceTypes 测试通过 UpdateField 修改切片、映射元素与指针字段
+func TestUpdateFieldReferenceTypes(t *testing.T) {
+	type config struct {
+		Tags  []string          `mapstructure:"tags"`
+		Extra map[string]string `mapstructure:"extra"`
+		Cache *struct {
+			Size int `mapstructure:"size"`
+		} `mapstructure:"cache"`
+	}
+	manager := newTestManager[config](t, "tags: [a, b]\nextra:\n  k: v\ncache:\n  size: 1\n", nil)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := manager.Watch(ctx)
+
+	// 写回失败时运行中的配置不应被修改
+	file := manager.vp.ConfigFileUsed()
+	data, _ := os.ReadFile(file)
+	if err := os.Remove(file); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.UpdateField(func(c *config) { c.Tags[0] = "x" }); err == nil {
+		t.Fatal("配置文件不存在时写回应失败")
+	}
+	if cfg, _ := manager.GetConfig(); cfg.Tags[0] != "a" {
+		t.Errorf("写回失败后配置不应变化: %v", cfg.Tags)
+	}
+	if err := os.WriteFile(file, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	version := manager.Version()
+	if err := manager.UpdateField(func(c *config) {
+		c.Tags[0] = "x"
+		c.Extra["k"] = "w"
+		c.Cache.Size = 2
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if manager.Version() != version+1 {
+		t.Errorf("版本号应递增一次: %d -> %d", version, manager.Version())
+	}
+	written, _ := os.ReadFile(file)
+	for _, want := range []string{"- x", "k: w", "size: 2"} {
+		if !strings.Contains(string(written), want) {
+			t.Errorf("配置文件中缺少 %q:\n%s", want, written)
+		}
+	}
+	select {
+	case event := <-events:
+		if event.Old.Tags[0] != "a" || event.Old.Extra["k"] != "v" || event.Old.Cache.Size != 1 {
+			t.Errorf("事件中的旧配置不应被修改: %+v", event.Old)
+		}
+		if len(event.ChangedKeys) != 3 {
+			t.Errorf("ChangedKeys 错误: %v", event.ChangedKeys)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("应发送变更事件")
+	}
+}
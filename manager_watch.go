@@ -0,0 +1,217 @@
+package configx
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ChangeEvent Watch 通道中的配置变更事件
+type ChangeEvent[T any] struct {
+	// Old 变更前的配置
+	Old T
+	// New 变更后的配置
+	New T
+	// ChangedKeys 值发生变化的配置字段路径
+	ChangedKeys []string
+	// Version 变更后的配置版本号
+	Version uint64
+	// Trigger 触发来源
+	Trigger ReloadTrigger
+	// Time 变更时间
+	Time time.Time
+}
+
+// OverflowPolicy Watch 通道缓冲区已满时的处理策略
+type OverflowPolicy int
+
+const (
+	// OverflowDropOldest 丢弃最早的未读事件（默认）
+	OverflowDropOldest OverflowPolicy = iota
+	// OverflowCoalesce 将未读事件合并为一个：Old 取最早的事件，New 取最新的事件，ChangedKeys 取并集
+	OverflowCoalesce
+	// OverflowBlock 阻塞热重载直到事件被读取（或 ctx 结束、管理器关闭）
+	OverflowBlock
+)
+
+// defaultWatchBuffer Watch 通道的默认缓冲区大小
+const defaultWatchBuffer = 16
+
+// WatchOption Watch 选项
+type WatchOption func(*watchConfig)
+
+// watchConfig Watch 配置
+type watchConfig struct {
+	keys     []string
+	buffer   int
+	overflow OverflowPolicy
+}
+
+// WatchKeys 只接收指定字段（或其子字段、父字段）发生变化的事件
+func WatchKeys(paths ...string) WatchOption {
+	return func(c *watchConfig) {
+		for _, p := range paths {
+			c.keys = append(c.keys, strings.ToLower(p))
+		}
+	}
+}
+
+// WatchBuffer 设置通道缓冲区大小（默认 16，最小 1）
+func WatchBuffer(size int) WatchOption {
+	return func(c *watchConfig) { c.buffer = max(size, 1) }
+}
+
+// WatchOverflow 设置缓冲区已满时的处理策略
+func WatchOverflow(policy OverflowPolicy) WatchOption {
+	return func(c *watchConfig) { c.overflow = policy }
+}
+
+// watcher 一个 Watch 订阅
+type watcher[T any] struct {
+	config watchConfig
+	ch     chan ChangeEvent[T]
+	ctx    context.Context
+	mu     sync.Mutex
+	closed bool
+}
+
+// Watch 以通道形式订阅配置变更
+// 每次热重载改变了配置（或 WatchKeys 指定的字段）时发送一个事件；
+// ctx 结束或管理器关闭时通道被关闭
+// 返回值：
+//
+//	<-chan ChangeEvent[T]: 配置变更事件通道
+//
+// 示例：
+//
+//	for event := range manager.Watch(ctx, configx.WatchKeys("server"), configx.WatchOverflow(configx.OverflowCoalesce)) {
+//	    server.SetTimeouts(event.New.Server.ReadTimeout, event.New.Server.WriteTimeout)
+//	}
+func (m *Manager[T]) Watch(ctx context.Context, opts ...WatchOption) <-chan ChangeEvent[T] {
+	w := &watcher[T]{config: watchConfig{buffer: defaultWatchBuffer}, ctx: ctx}
+	for _, opt := range opts {
+		opt(&w.config)
+	}
+	w.ch = make(chan ChangeEvent[T], w.config.buffer)
+
+	if m.isClosed() {
+		close(w.ch)
+		return w.ch
+	}
+
+	m.watchMutex.Lock()
+	m.watchers = append(m.watchers, w)
+	m.watchMutex.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-m.closed:
+		}
+		m.watchMutex.Lock()
+		for i, existing := range m.watchers {
+			if existing == w {
+				m.watchers = append(append([]*watcher[T](nil), m.watchers[:i]...), m.watchers[i+1:]...)
+				break
+			}
+		}
+		m.watchMutex.Unlock()
+
+		w.mu.Lock()
+		w.closed = true
+		close(w.ch)
+		w.mu.Unlock()
+	}()
+	return w.ch
+}
+
+// notifyWatchers 向订阅者发送配置变更事件
+func (m *Manager[T]) notifyWatchers(event ChangeEvent[T]) {
+	if len(event.ChangedKeys) == 0 {
+		return
+	}
+	m.watchMutex.Lock()
+	watchers := append([]*watcher[T](nil), m.watchers...)
+	m.watchMutex.Unlock()
+
+	for _, w := range watchers {
+		if len(w.config.keys) > 0 && !watchMatches(w.config.keys, event.ChangedKeys) {
+			continue
+		}
+		m.sendWatchEvent(w, event)
+	}
+}
+
+// sendWatchEvent 按溢出策略发送事件
+func (m *Manager[T]) sendWatchEvent(w *watcher[T], event ChangeEvent[T]) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return
+	}
+
+	select {
+	case w.ch <- event:
+		return
+	default:
+	}
+
+	switch w.config.overflow {
+	case OverflowBlock:
+		select {
+		case w.ch <- event:
+		case <-w.ctx.Done():
+		case <-m.closed:
+		}
+	case OverflowCoalesce:
+		// 合并全部未读事件与新事件
+		merged := event
+		first := true
+		for drained := false; !drained; {
+			select {
+			case pending := <-w.ch:
+				if first {
+					merged.Old = pending.Old
+					first = false
+				}
+				merged.ChangedKeys = mergeKeys(merged.ChangedKeys, pending.ChangedKeys)
+			default:
+				drained = true
+			}
+		}
+		w.ch <- merged
+	default:
+		// 丢弃最早的事件（发送方持有锁，腾出的位置不会被其他发送方占用）
+		select {
+		case <-w.ch:
+		default:
+		}
+		w.ch <- event
+	}
+}
+
+// watchMatches 判断订阅的字段是否发生变化
+func watchMatches(watchKeys, changed []string) bool {
+	for _, key := range watchKeys {
+		if keysOverlap(key, changed) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeKeys 合并两个字段路径列表（去重并排序）
+func mergeKeys(a, b []string) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	merged := make([]string, 0, len(a)+len(b))
+	for _, key := range append(append([]string(nil), a...), b...) {
+		if !seen[key] {
+			seen[key] = true
+			merged = append(merged, key)
+		}
+	}
+	sort.Strings(merged)
+	return merged
+}
@@ -0,0 +1,193 @@
+package configx
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type watchTestConfig struct {
+	Name   string `mapstructure:"name"`
+	Server struct {
+		Port int `mapstructure:"port"`
+	} `mapstructure:"server"`
+}
+
+// newWatchTestManager 创建关闭文件监听、通过 Reload 触发变更的管理器
+func newWatchTestManager(t *testing.T) (*Manager[watchTestConfig], *Option) {
+	t.Helper()
+	opts := NewOption()
+	opts.DisableWatch = true
+	manager := newTestManager[watchTestConfig](t, "name: a\nserver:\n  port: 1\n", opts)
+	if err := manager.Init(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { manager.Close() })
+	return manager, opts
+}
+
+// watchReload 写入配置文件并手动重载
+func watchReload(t *testing.T, manager *Manager[watchTestConfig], opts *Option, content string) {
+	t.Helper()
+	if err := os.WriteFile(opts.File(), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.Reload(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestWatchEvents 测试 Watch 事件内容与按字段过滤
+func TestWatchEvents(t *testing.T) {
+	manager, opts := newWatchTestManager(t)
+	all := manager.Watch(context.Background())
+	server := manager.Watch(context.Background(), WatchKeys("Server"))
+
+	watchReload(t, manager, opts, "name: b\nserver:\n  port: 1\n")
+	watchReload(t, manager, opts, "name: b\nserver:\n  port: 2\n")
+
+	first := <-all
+	if first.Old.Name != "a" || first.New.Name != "b" || first.Trigger != TriggerManual {
+		t.Errorf("事件内容错误: %+v", first)
+	}
+	if !reflect.DeepEqual(first.ChangedKeys, []string{"name"}) {
+		t.Errorf("ChangedKeys 错误: %v", first.ChangedKeys)
+	}
+	second := <-all
+	if second.Version != manager.Version() {
+		t.Errorf("Version 错误: %d", second.Version)
+	}
+
+	event := <-server
+	if event.New.Server.Port != 2 || !reflect.DeepEqual(event.ChangedKeys, []string{"server.port"}) {
+		t.Errorf("过滤后的事件错误: %+v", event)
+	}
+	select {
+	case extra := <-server:
+		t.Errorf("不应收到与 server 无关的事件: %+v", extra)
+	default:
+	}
+}
+
+// TestWatchUpdateField 测试 UpdateField 与 Set 的修改分发给 Watch 订阅者
+func TestWatchUpdateField(t *testing.T) {
+	manager, _ := newWatchTestManager(t)
+	events := manager.Watch(context.Background())
+
+	if err := manager.UpdateField(func(c *watchTestConfig) { c.Name = "b" }); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.Set("server.port", 2); err != nil {
+		t.Fatal(err)
+	}
+
+	first := <-events
+	if first.Old.Name != "a" || first.New.Name != "b" || first.Trigger != TriggerUpdate {
+		t.Errorf("UpdateField 事件错误: %+v", first)
+	}
+	if !reflect.DeepEqual(first.ChangedKeys, []string{"name"}) {
+		t.Errorf("ChangedKeys 错误: %v", first.ChangedKeys)
+	}
+	second := <-events
+	if second.New.Server.Port != 2 || !reflect.DeepEqual(second.ChangedKeys, []string{"server.port"}) {
+		t.Errorf("Set 事件错误: %+v", second)
+	}
+	if second.Version != manager.Version() {
+		t.Errorf("Version 错误: %d", second.Version)
+	}
+
+	// 值未变化时不产生事件
+	if err := manager.Set("server.port", 2); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case extra := <-events:
+		t.Errorf("值未变化时不应产生事件: %+v", extra)
+	default:
+	}
+}
+
+// TestWatchOverflow 测试缓冲区已满时的溢出策略
+func TestWatchOverflow(t *testing.T) {
+	manager, opts := newWatchTestManager(t)
+	dropOldest := manager.Watch(context.Background(), WatchBuffer(1))
+	coalesce := manager.Watch(context.Background(), WatchBuffer(1), WatchOverflow(OverflowCoalesce))
+
+	watchReload(t, manager, opts, "name: b\nserver:\n  port: 1\n")
+	watchReload(t, manager, opts, "name: b\nserver:\n  port: 2\n")
+
+	event := <-dropOldest
+	if event.Old.Server.Port != 1 || event.New.Server.Port != 2 {
+		t.Errorf("应只保留最新的事件: %+v", event)
+	}
+
+	merged := <-coalesce
+	if merged.Old.Name != "a" || merged.New.Server.Port != 2 {
+		t.Errorf("合并事件应从最早的 Old 到最新的 New: %+v", merged)
+	}
+	if !reflect.DeepEqual(merged.ChangedKeys, []string{"name", "server.port"}) {
+		t.Errorf("合并事件的 ChangedKeys 错误: %v", merged.ChangedKeys)
+	}
+}
+
+// TestWatchBlock 测试阻塞策略与 ctx 结束时关闭通道
+func TestWatchBlock(t *testing.T) {
+	manager, opts := newWatchTestManager(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := manager.Watch(ctx, WatchBuffer(1), WatchOverflow(OverflowBlock))
+
+	watchReload(t, manager, opts, "name: b\nserver:\n  port: 1\n")
+
+	done := make(chan struct{})
+	go func() {
+		watchReload(t, manager, opts, "name: c\nserver:\n  port: 1\n")
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("缓冲区已满时重载应阻塞")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if event := <-ch; event.New.Name != "b" {
+		t.Errorf("事件顺序错误: %+v", event)
+	}
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("读取事件后重载应继续")
+	}
+
+	cancel()
+	if event := <-ch; event.New.Name != "c" {
+		t.Errorf("关闭前应保留未读事件: %+v", event)
+	}
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Error("ctx 结束后通道应关闭")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("ctx 结束后通道未关闭")
+	}
+}
+
+// TestWatchClose 测试管理器关闭时关闭通道
+func TestWatchClose(t *testing.T) {
+	manager, _ := newWatchTestManager(t)
+	ch := manager.Watch(context.Background())
+	manager.Close()
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Error("不应收到事件")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("管理器关闭后通道未关闭")
+	}
+	if _, ok := <-manager.Watch(context.Background()); ok {
+		t.Error("管理器关闭后 Watch 应返回已关闭的通道")
+	}
+}
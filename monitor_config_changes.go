@@ -1,115 +1,144 @@
 package configx
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
-	"time"
+	"sort"
 
 	"github.com/fsnotify/fsnotify"
 )
 
 // Unmarshal 解析配置到结构体
 func (m *Manager[T]) Unmarshal() error {
-	var newConfig T
-	if err := m.vp.Unmarshal(&newConfig); err != nil {
+	_, err := m.unmarshal()
+	return err
+}
+
+// unmarshal 解析配置到结构体并替换当前配置
+// 已有配置时（热重载）执行 OnReload 注册的两阶段处理器，被否决或提交失败时保持原有配置；
+// 成功后将新配置应用到 Register 注册的组件
+// 返回值：
+//
+//	ChangeSummary: 变化的字段、待重启字段与组件应用结果（首次加载时为空）
+//	error: 解析失败、类型不一致、被否决或提交失败时返回错误
+func (m *Manager[T]) unmarshal() (ChangeSummary, error) {
+	var summary ChangeSummary
+	parsed, meta, warnings, err := m.buildConfig()
+	m.executeHooks(warnings)
+	if err != nil {
 		m.executeHook(Error, HookContext{
 			Message: fmt.Sprintf("failed to unmarshal new config: %v", err),
+			Event:   EventValidationFailed,
+			File:    m.vp.ConfigFileUsed(),
+			Err:     err,
 		})
-		return errors.New(fmt.Sprintf("failed to unmarshal new config: %v", err))
+		return summary, fmt.Errorf("failed to unmarshal new config: %w", err)
+	}
+	newConfig := *parsed
+
+	m.rwMutex.RLock()
+	previous := m.config
+	m.rwMutex.RUnlock()
+
+	if previous == nil {
+		m.rwMutex.Lock()
+		m.config = &newConfig
+		m.rwMutex.Unlock()
+		m.storeMeta(meta)
+		m.markApplied(&newConfig)
+		return summary, nil
 	}
 
-	m.rwMutex.Lock()
-	defer m.rwMutex.Unlock()
+	oldConfig := *previous
+	changes := make(map[string][2]any)
+	if !compareStructs(oldConfig, newConfig, "", changes) {
+		m.executeHook(Error, HookContext{
+			Message: "config type mismatch, changes blocked",
+			Event:   EventValidationFailed,
+		})
+		return summary, errors.New(fmt.Sprintf("config type mismatch, changes blocked"))
+	}
 
-	if m.config != nil {
-		oldConfig := *m.config
-		changes := make(map[string][2]any)
+	// 需要重启的字段保留运行中的值
+	pending := m.preserveRestartFields(oldConfig, &newConfig)
+	sort.Strings(pending)
+	keys := changedKeys(oldConfig, newConfig)
 
-		if !compareStructs(oldConfig, newConfig, "", changes) {
-			m.executeHook(Error, HookContext{
-				Message: "config type mismatch, changes blocked",
-			})
-			return errors.New(fmt.Sprintf("config type mismatch, changes blocked"))
-		}
+	// 两阶段处理器在锁外执行，处理器中可以安全地调用 GetConfig
+	handlers := m.reloadHandlerList()
+	if err := prepareReload(handlers, oldConfig, newConfig); err != nil {
+		return summary, err
 	}
 
+	m.rwMutex.Lock()
 	m.config = &newConfig
-	return nil
+	m.rwMutex.Unlock()
+
+	if err := commitReload(handlers, oldConfig, newConfig); err != nil {
+		m.rwMutex.Lock()
+		m.config = previous
+		m.rwMutex.Unlock()
+		return summary, err
+	}
+	m.storeMeta(meta)
+	m.markApplied(&newConfig)
+
+	m.pendingRestart.Store(&pending)
+	if len(pending) > 0 {
+		m.executeHook(Warn, HookContext{
+			Message:     restartMessage(m.vp.ConfigFileUsed(), pending),
+			Event:       EventRestartRequired,
+			File:        m.vp.ConfigFileUsed(),
+			ChangedKeys: pending,
+		})
+	}
+
+	// 新配置已生效，组件应用失败只报告，不影响本次重载
+	applied, failures := m.applyComponents(oldConfig, newConfig, keys)
+	m.reportComponentFailures(failures)
+
+	summary.ChangedKeys = keys
+	summary.PendingRestart = pending
+	summary.Applied = applied
+	summary.ComponentErrors = failures
+	return summary, nil
 }
 
 // monitorConfigChanges 监听配置变更（带防抖与类型过滤）
-// 参数：
-//   handles: 配置变更时的回调函数列表
 // 功能：
 //   - 使用防抖机制避免频繁重载
-//   - 在配置变更时调用泛型 LoadConfig 重新加载
+//   - 在配置变更时执行与 Reload 相同的重载流程
 //   - 触发钩子记录配置变更事件
-//   - 执行开发者提供的回调函数
+//   - 按 OnChange 注册的执行策略分发回调函数
 //   - 确保重载失败时保持原有配置不变
 //   - 线程安全
-func (m *Manager[T]) monitorConfigChanges(handles []HandlerFunc) {
+func (m *Manager[T]) monitorConfigChanges() {
 	m.vp.WatchConfig()
 	m.vp.OnConfigChange(func(e fsnotify.Event) {
-		// 仅响应写入事件，忽略 CHMOD/RENAME 等
-		if e.Op != fsnotify.Write {
-			return
-		}
-
-		// 防抖处理：忽略短时间内的重复变更（使用 atomic 操作）
-		now := time.Now()
-		lastChangeNano := m.lastChangeNano.Load()
-		lastChangeTime := time.Unix(0, lastChangeNano)
-		if now.Sub(lastChangeTime) < m.debounceDur {
+		// 仅响应写入事件，忽略 CHMOD/RENAME 等；管理器关闭后不再重载
+		if e.Op != fsnotify.Write || m.isClosed() {
 			return
 		}
-		m.lastChangeNano.Store(now.UnixNano())
-
-		// 触发钩子：检测到配置文件变更
-		m.executeHook(Info, HookContext{
-			Message: fmt.Sprintf("[config] 检测到文件变更: %s", e.Name),
-		})
-
-		// 保存当前配置的副本，以便在重载失败时恢复
-		m.rwMutex.RLock()
-		oldConfig := m.config
-		m.rwMutex.RUnlock()
-
-		// 重新加载配置文件
-		if err := m.vp.ReadInConfig(); err != nil {
-			m.executeHook(Error, HookContext{
-				Message: fmt.Sprintf("[config] 重新加载配置文件失败: %v", err),
-			})
+		// 写回产生的变更已经生效，不再重复重载
+		if m.selfWritten(e.Name) {
 			return
 		}
 
-		// 解析配置到结构体
-		if err := m.Unmarshal(); err != nil {
-			// 解析失败，恢复原有配置
-			m.rwMutex.Lock()
-			m.config = oldConfig
-			m.rwMutex.Unlock()
-			
-			m.executeHook(Error, HookContext{
-				Message: fmt.Sprintf("[config] 解析配置失败，保持原有配置: %v", err),
+		reload := func() {
+			// 触发钩子：检测到配置文件变更
+			m.executeHook(Info, HookContext{
+				Message: fmt.Sprintf("[config] 检测到文件变更: %s", e.Name),
+				Event:   EventFileChanged,
+				File:    e.Name,
 			})
-			return
-		}
-
-		// 触发钩子：配置重新加载成功
-		m.executeHook(Info, HookContext{
-			Message: "[config] 配置重新加载成功",
-		})
-
-		// 创建回调上下文，包含管理器实例引用
-		ctx := &Context{
-			FSEvent: e,
-			manager: m,
+			m.reload(context.Background(), TriggerWatch, e)
 		}
 
-		// 执行开发者提供的回调函数
-		for _, handle := range handles {
-			handle(ctx)
+		// 防抖处理：短时间内的重复变更合并为窗口结束时的一次重载（使用 atomic 操作）
+		if m.debounce(reload) {
+			reload()
 		}
 	})
 }
@@ -155,7 +184,8 @@ func compareStructs(oldObj, newObj any, prefix string, changes map[string][2]any
 		}
 
 		if !reflect.DeepEqual(oldField.Interface(), newField.Interface()) {
-			changes[fullName] = [2]any{oldField.Interface(), newField.Interface()}
+			// 敏感字段（Secret 类型或 secret:"true" 标签）只记录脱敏值
+			changes[fullName] = redactChange(oldVal.Type().Field(i), oldField.Interface(), newField.Interface())
 		}
 	}
 
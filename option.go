@@ -1,7 +1,9 @@
 package configx
 
 import (
+	"crypto/ed25519"
 	"github.com/kawaiirei0/configx/v2/utils"
+	"os"
 	"time"
 )
 
@@ -12,6 +14,41 @@ type Option struct {
 	Filename    OptionString
 	Filepath    OptionString
 	DebounceDur OptionTimeDuration
+
+	// Strict 严格模式：配置文件中存在无法映射到结构体的字段时拒绝加载
+	Strict bool
+	// StrictAllowKeys 严格模式下允许出现的自由字段路径，如 "plugins" 或 "extras.*"
+	StrictAllowKeys []string
+	// RewriteAliases UpdateField 写回配置文件时，将弃用字段改写为 Alias 注册的新字段名
+	RewriteAliases bool
+	// MigrateWriteBack 执行版本迁移后将结果写回配置文件，原文件备份为 .bak
+	MigrateWriteBack bool
+	// Interpolate 加载时解析配置值中的 ${ENV}、${file:...}、${key} 等插值表达式
+	Interpolate bool
+	// KeyProvider 解密配置值中 ENC[AES256_GCM,...] 密文所用的密钥提供者
+	KeyProvider KeyProvider
+	// FileDecrypter 解析前解密整个配置文件（见 AESGCMFileDecrypter、X25519AESGCMFileDecrypter）
+	FileDecrypter FileDecrypter
+	// SignatureKey 设置后，加载与热重载前校验 <配置文件>.sig 分离签名所用的 ed25519 公钥
+	SignatureKey ed25519.PublicKey
+	// FilePermission 配置包含 Secret 字段时的文件权限检查策略，默认 PermissionWarn
+	FilePermission PermissionPolicy
+	// FileMode 创建默认配置文件时使用的权限，为 0 时包含 Secret 字段的配置使用 0600，否则使用 0644
+	FileMode os.FileMode
+	// DisableWatch Init 时不监听配置文件变更，由 Reload 等方式触发重载
+	DisableWatch bool
+	// ReloadSignals 收到这些信号时重载配置，如 syscall.SIGHUP
+	ReloadSignals []os.Signal
+	// ReloadTouchFile 控制文件路径（相对路径基于配置目录），文件被创建、写入或 touch 时重载配置
+	ReloadTouchFile string
+	// ReloadInterval 定时重载间隔，适用于无法监听的配置来源；内容哈希未变化时跳过解析
+	ReloadInterval time.Duration
+	// ReloadIntervalKey 配置结构体中覆盖 ReloadInterval 的字段路径（如 "reload.interval"），取自已生效的配置；为 0 时暂停定时重载，改为正数后恢复
+	ReloadIntervalKey string
+	// ReloadJitter 定时重载间隔的随机抖动比例（0~1），如 0.1 表示 ±10%
+	ReloadJitter float64
+	// ReloadMaxBackoff 定时重载失败时的最大退避间隔，默认为重载间隔的 10 倍
+	ReloadMaxBackoff time.Duration
 }
 
 // NewOption 创建默认配置
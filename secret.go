@@ -0,0 +1,355 @@
+package configx
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Redacted 敏感值的脱敏显示形式
+const Redacted = "******"
+
+// SecretTag 标记普通字段为敏感字段的结构体标签，如 `secret:"true"`
+const SecretTag = "secret"
+
+// Secret 敏感字符串类型
+// 解析方式与普通字符串相同，但在 fmt、JSON、YAML、slog 以及 configx 的钩子消息和变更记录中
+// 均显示为 ******，读取真实值必须显式调用 Reveal()
+//
+// 示例：
+//
+//	type DatabaseConfig struct {
+//	    Password configx.Secret `mapstructure:"password"`
+//	}
+//
+//	fmt.Println(cfg.Database.Password)          // ******
+//	dsn := cfg.Database.Password.Reveal()       // 真实密码
+type Secret string
+
+// Reveal 返回真实值
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+// String 实现 fmt.Stringer，返回脱敏值
+func (s Secret) String() string {
+	return Redacted
+}
+
+// GoString 实现 fmt.GoStringer，使 %#v 同样脱敏
+func (s Secret) GoString() string {
+	return fmt.Sprintf("configx.Secret(%q)", Redacted)
+}
+
+// Format 实现 fmt.Formatter，任何格式化动词都输出脱敏值
+func (s Secret) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'q':
+		fmt.Fprintf(f, "%q", Redacted)
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprint(f, s.GoString())
+			return
+		}
+		fmt.Fprint(f, Redacted)
+	default:
+		fmt.Fprint(f, Redacted)
+	}
+}
+
+// MarshalJSON 实现 json.Marshaler，输出脱敏值
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + Redacted + `"`), nil
+}
+
+// MarshalYAML 实现 yaml.Marshaler，输出脱敏值
+func (s Secret) MarshalYAML() (any, error) {
+	return Redacted, nil
+}
+
+// LogValue 实现 slog.LogValuer，输出脱敏值
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(Redacted)
+}
+
+// secretType Secret 的反射类型
+var secretType = reflect.TypeOf(Secret(""))
+
+// isSecretField 判断结构体字段是否为敏感字段
+func isSecretField(field reflect.StructField) bool {
+	if indirectType(field.Type) == secretType {
+		return true
+	}
+	return field.Tag.Get(SecretTag) == "true"
+}
+
+// redactChange 对敏感字段的变更值进行脱敏
+func redactChange(field reflect.StructField, oldValue, newValue any) [2]any {
+	if isSecretField(field) {
+		return [2]any{Redacted, Redacted}
+	}
+	return [2]any{oldValue, newValue}
+}
+
+// containsSecret 判断类型中是否包含 Secret 字段
+func containsSecret(t reflect.Type) bool {
+	return containsSecretType(t, make(map[reflect.Type]bool))
+}
+
+// containsSecretType 递归检查类型（visited 防止递归类型死循环）
+func containsSecretType(t reflect.Type, visited map[reflect.Type]bool) bool {
+	if t == secretType {
+		return true
+	}
+	if visited[t] {
+		return false
+	}
+	visited[t] = true
+
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return containsSecretType(t.Elem(), visited)
+	case reflect.Map:
+		return containsSecretType(t.Elem(), visited)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() && containsSecretType(t.Field(i).Type, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// restoreSecrets 将 src 中的 Secret 值复制到 dst
+// JSON 深拷贝会把 Secret 序列化为脱敏值，因此拷贝完成后需要恢复真实值
+func restoreSecrets(src, dst reflect.Value) {
+	if src.Type() == secretType {
+		if dst.CanSet() {
+			dst.Set(src)
+		}
+		return
+	}
+
+	switch src.Kind() {
+	case reflect.Pointer:
+		if !src.IsNil() && !dst.IsNil() {
+			restoreSecrets(src.Elem(), dst.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			if src.Type().Field(i).IsExported() {
+				restoreSecrets(src.Field(i), dst.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < min(src.Len(), dst.Len()); i++ {
+			restoreSecrets(src.Index(i), dst.Index(i))
+		}
+	case reflect.Map:
+		if dst.IsNil() {
+			return
+		}
+		iter := src.MapRange()
+		for iter.Next() {
+			current := dst.MapIndex(iter.Key())
+			if !current.IsValid() {
+				continue
+			}
+			// 映射元素不可寻址，需要复制后重新写回
+			elem := reflect.New(current.Type()).Elem()
+			elem.Set(current)
+			restoreSecrets(iter.Value(), elem)
+			dst.SetMapIndex(iter.Key(), elem)
+		}
+	}
+}
+
+// Redact 返回配置的副本，其中 `secret:"true"` 标签字段被替换为脱敏值
+// Secret 类型会自行脱敏；普通类型的标签字段无法改变自身的 fmt、JSON、YAML 输出，
+// 打印或序列化配置（调试输出、诊断接口）前应先调用 Redact
+// 字符串字段替换为 ******，其他类型的标签字段置为零值；原配置不受影响
+//
+// 示例：
+//
+//	cfg, _ := manager.GetConfig()
+//	slog.Info("配置已加载", "config", configx.Redact(cfg))
+func Redact[V any](v V) V {
+	value := reflect.ValueOf(&v).Elem()
+	if !containsSecretTag(indirectType(value.Type()), make(map[reflect.Type]bool)) {
+		return v
+	}
+	return redactValue(value).Interface().(V)
+}
+
+// redactValue 返回脱敏后的副本，路径上的指针、切片与映射都会被复制，不修改原值
+func redactValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type().Elem())
+		out.Elem().Set(redactValue(v.Elem()))
+		return out
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(redactValue(v.Elem()))
+		return out
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if field.Tag.Get(SecretTag) == "true" && indirectType(field.Type) != secretType {
+				out.Field(i).Set(redactedValue(field.Type))
+				continue
+			}
+			out.Field(i).Set(redactValue(v.Field(i)))
+		}
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(redactValue(v.Index(i)))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(redactValue(v.Index(i)))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), redactValue(iter.Value()))
+		}
+		return out
+	}
+	return v
+}
+
+// redactedValue 标签字段的脱敏值：字符串（或字符串指针）为 ******，其他类型为零值
+func redactedValue(t reflect.Type) reflect.Value {
+	if t.Kind() == reflect.String {
+		return reflect.ValueOf(Redacted).Convert(t)
+	}
+	if t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.String {
+		out := reflect.New(t.Elem())
+		out.Elem().Set(reflect.ValueOf(Redacted).Convert(t.Elem()))
+		return out
+	}
+	return reflect.Zero(t)
+}
+
+// redactedError 隐藏了敏感值的错误，Unwrap 仍返回原始错误
+type redactedError struct {
+	message string
+	err     error
+}
+
+// Error 实现 error 接口
+func (e *redactedError) Error() string {
+	return e.message
+}
+
+// Unwrap 返回原始错误
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// redactSettingsError 将错误信息中出现的敏感字段原始值替换为脱敏值
+// 解码错误（如类型转换失败）与补丁 test 操作的错误会在信息中包含字段的原始值，这些信息会出现在钩子与返回的错误中
+func redactSettingsError(err error, settings map[string]any, t reflect.Type) error {
+	if err == nil {
+		return nil
+	}
+	var values []string
+	collectSecretValues(settings, indirectType(t), &values)
+	message := err.Error()
+	redacted := message
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		// 错误信息中的值带有引号，只替换带引号的形式，避免误改信息中的其他内容
+		quotes := []string{"'" + value + "'", "`" + value + "`", strconv.Quote(value), `"` + value + `"`}
+		if encoded, err := json.Marshal(value); err == nil {
+			quotes = append(quotes, string(encoded))
+		}
+		for _, quoted := range quotes {
+			redacted = strings.ReplaceAll(redacted, quoted, strconv.Quote(Redacted))
+		}
+	}
+	if redacted == message {
+		return err
+	}
+	return &redactedError{message: redacted, err: err}
+}
+
+// collectSecretValues 按配置类型收集配置映射中敏感字段的原始值
+func collectSecretValues(value any, t reflect.Type, values *[]string) {
+	switch val := value.(type) {
+	case map[string]any:
+		if t.Kind() == reflect.Map {
+			for _, item := range val {
+				if indirectType(t.Elem()) == secretType {
+					*values = append(*values, fmt.Sprint(item))
+					continue
+				}
+				collectSecretValues(item, indirectType(t.Elem()), values)
+			}
+			return
+		}
+		if t.Kind() != reflect.Struct {
+			return
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, opts, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+			if strings.Contains(opts, "squash") || (field.Anonymous && name == "") {
+				collectSecretValues(val, indirectType(field.Type), values)
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			item, ok := val[strings.ToLower(name)]
+			if !ok {
+				continue
+			}
+			if isSecretField(field) {
+				*values = append(*values, fmt.Sprint(item))
+				continue
+			}
+			collectSecretValues(item, indirectType(field.Type), values)
+		}
+	case []any:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return
+		}
+		for _, item := range val {
+			collectSecretValues(item, indirectType(t.Elem()), values)
+		}
+	}
+}
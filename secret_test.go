@@ -0,0 +1,189 @@
+package configx
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+type secretTestConfig struct {
+	Database struct {
+		User     string `mapstructure:"user"`
+		Password Secret `mapstructure:"password"`
+		Token    string `mapstructure:"token" secret:"true"`
+	} `mapstructure:"database"`
+	Keys map[string]Secret `mapstructure:"keys"`
+}
+
+// TestSecretRedaction 测试 Secret 在各种输出中均被脱敏
+func TestSecretRedaction(t *testing.T) {
+	s := Secret("p@ss")
+
+	for _, out := range []string{
+		fmt.Sprint(s), fmt.Sprintf("%s|%v|%+v|%q|%x", s, s, s, s, s), fmt.Sprintf("%#v", s),
+		fmt.Sprintf("%v", struct{ P Secret }{s}),
+	} {
+		if strings.Contains(out, "p@ss") {
+			t.Errorf("fmt 输出泄露真实值: %s", out)
+		}
+	}
+
+	data, _ := json.Marshal(struct{ P Secret }{s})
+	if string(data) != `{"P":"******"}` {
+		t.Errorf("JSON 输出错误: %s", data)
+	}
+	data, _ = yaml.Marshal(struct{ P Secret }{s})
+	if strings.Contains(string(data), "p@ss") {
+		t.Errorf("YAML 输出泄露真实值: %s", data)
+	}
+	if s.Reveal() != "p@ss" {
+		t.Errorf("Reveal() = %q", s.Reveal())
+	}
+}
+
+// TestSecretSurvivesGetConfig 测试 GetConfig 深拷贝保留真实值
+func TestSecretSurvivesGetConfig(t *testing.T) {
+	manager := newTestManager[secretTestConfig](t, "database:\n  password: p@ss\nkeys:\n  api: k3y\n", nil)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+
+	cfg, err := manager.GetConfig()
+	if err != nil {
+		t.Fatalf("获取配置失败: %v", err)
+	}
+	if cfg.Database.Password.Reveal() != "p@ss" || cfg.Keys["api"].Reveal() != "k3y" {
+		t.Errorf("深拷贝丢失真实值: %q %q", cfg.Database.Password.Reveal(), cfg.Keys["api"].Reveal())
+	}
+}
+
+// TestSecretChangesRedacted 测试变更记录中的敏感字段被脱敏
+func TestSecretChangesRedacted(t *testing.T) {
+	var oldCfg, newCfg secretTestConfig
+	oldCfg.Database.Password, newCfg.Database.Password = "old", "new"
+	oldCfg.Database.Token, newCfg.Database.Token = "t1", "t2"
+	oldCfg.Database.User, newCfg.Database.User = "a", "b"
+
+	changes := make(map[string][2]any)
+	compareStructs(oldCfg, newCfg, "", changes)
+
+	for _, key := range []string{"Database.Password", "Database.Token"} {
+		if changes[key] != [2]any{Redacted, Redacted} {
+			t.Errorf("%s 未脱敏: %v", key, changes[key])
+		}
+	}
+	if changes["Database.User"] != [2]any{"a", "b"} {
+		t.Errorf("普通字段不应脱敏: %v", changes["Database.User"])
+	}
+}
+
+// TestUpdateFieldWritesSecret 测试 UpdateField 写回 Secret 的真实值
+func TestUpdateFieldWritesSecret(t *testing.T) {
+	manager := newTestManager[secretTestConfig](t, "database:\n  password: old\n", nil)
+	if err := manager.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	if err := manager.UpdateField(func(c *secretTestConfig) { c.Database.Password = "new" }); err != nil {
+		t.Fatalf("更新配置失败: %v", err)
+	}
+
+	data, _ := os.ReadFile(manager.vp.ConfigFileUsed())
+	if !strings.Contains(string(data), `password: "new"`) {
+		t.Errorf("写回内容错误:\n%s", data)
+	}
+}
+
+// TestRedactTaggedFields 测试 Redact 脱敏 secret:"true" 标签字段且不修改原配置
+func TestRedactTaggedFields(t *testing.T) {
+	type nested struct {
+		APIKey string `mapstructure:"api_key" secret:"true"`
+		Name   string `mapstructure:"name"`
+	}
+	type config struct {
+		Base     secretTestConfig   `mapstructure:"base"`
+		Services []nested           `mapstructure:"services"`
+		Backup   *nested            `mapstructure:"backup"`
+		Tenants  map[string]*nested `mapstructure:"tenants"`
+	}
+	var cfg config
+	cfg.Base.Database.Token = "tok-123"
+	cfg.Base.Database.Password = "p@ss"
+	cfg.Services = []nested{{APIKey: "key-1", Name: "a"}}
+	cfg.Backup = &nested{APIKey: "key-2"}
+	cfg.Tenants = map[string]*nested{"t": {APIKey: "key-3"}}
+
+	redacted := Redact(cfg)
+	data, _ := json.Marshal(redacted)
+	text := fmt.Sprintf("%+v %v %s", redacted, *redacted.Backup, data)
+	yamlData, _ := yaml.Marshal(redacted)
+	for _, leaked := range []string{"tok-123", "p@ss", "key-1", "key-2", "key-3"} {
+		if strings.Contains(text, leaked) || strings.Contains(string(yamlData), leaked) {
+			t.Errorf("Redact 输出泄露真实值 %s:\n%s\n%s", leaked, text, yamlData)
+		}
+	}
+	if redacted.Services[0].Name != "a" || redacted.Base.Database.Password.Reveal() != "p@ss" {
+		t.Errorf("非标签字段应保持原值: %+v", redacted)
+	}
+	if cfg.Base.Database.Token != "tok-123" || cfg.Services[0].APIKey != "key-1" || cfg.Backup.APIKey != "key-2" || cfg.Tenants["t"].APIKey != "key-3" {
+		t.Error("Redact 不应修改原配置")
+	}
+}
+
+// TestDecodeErrorRedactsSecret 测试解码错误中的敏感字段原始值被脱敏
+func TestDecodeErrorRedactsSecret(t *testing.T) {
+	type config struct {
+		Pattern *regexp.Regexp `mapstructure:"pattern" secret:"true"`
+		Filter  *regexp.Regexp `mapstructure:"filter"`
+	}
+	var messages []string
+	manager := newTestManager[config](t, "pattern: \"(hunter2\"\nfilter: \"(many\"\n", nil)
+	manager.AddHook(Error, func(ctx HookContext) { messages = append(messages, ctx.Message) })
+	err := manager.LoadConfig()
+	if err == nil {
+		t.Fatal("期望解码失败")
+	}
+	if strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("错误信息泄露敏感值: %v", err)
+	}
+	if !strings.Contains(err.Error(), "many") {
+		t.Errorf("普通字段的值应保留在错误信息中: %v", err)
+	}
+	for _, message := range messages {
+		if strings.Contains(message, "hunter2") {
+			t.Errorf("钩子消息泄露敏感值: %s", message)
+		}
+	}
+}
+
+// TestDefaultFileWritesSecret 测试生成默认配置文件时写入 Secret 的真实默认值
+func TestDefaultFileWritesSecret(t *testing.T) {
+	var defaults secretTestConfig
+	defaults.Database.User = "admin"
+	defaults.Database.Password = "changeme"
+	defaults.Database.Token = "token"
+
+	dir := t.TempDir()
+	opts := NewOption()
+	opts.Filepath.Set(OptionString(dir))
+	opts.Filename.Set("config.yaml")
+	opts.DisableWatch = true
+	manager := NewManager(defaults).SetOption(opts)
+	if err := manager.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer manager.Close()
+
+	data, _ := os.ReadFile(opts.File())
+	if strings.Contains(string(data), Redacted) {
+		t.Errorf("默认配置文件不应包含脱敏值:\n%s", data)
+	}
+	cfg, _ := manager.GetConfig()
+	if cfg.Database.Password.Reveal() != "changeme" || cfg.Database.Token != "token" || cfg.Database.User != "admin" {
+		t.Errorf("默认配置文件应能按原值加载: %+v", Redact(cfg))
+	}
+}
@@ -0,0 +1,129 @@
+package configx
+
+import "strings"
+
+// lookupSetting 按字段路径读取配置映射中的值
+// 路径格式与 Viper 一致（点号分隔，不区分大小写），切片元素使用 [index]
+func lookupSetting(settings map[string]any, path string) (any, bool) {
+	var cur any = settings
+	for _, seg := range splitKeyPath(path) {
+		if idx, ok := seg.index(); ok {
+			items, ok := cur.([]any)
+			if !ok || idx >= len(items) {
+				return nil, false
+			}
+			cur = items[idx]
+			continue
+		}
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		key, ok := findSettingKey(m, seg.name)
+		if !ok {
+			return nil, false
+		}
+		cur = m[key]
+	}
+	return cur, true
+}
+
+// storeSetting 按字段路径写入配置映射，缺失的中间层级会自动创建
+// 返回值：
+//
+//	bool: 路径穿过非映射值或越界的切片下标时返回 false
+func storeSetting(settings map[string]any, path string, value any) bool {
+	segs := splitKeyPath(path)
+	if len(segs) == 0 {
+		return false
+	}
+
+	var cur any = settings
+	for i, seg := range segs {
+		last := i == len(segs)-1
+		if idx, ok := seg.index(); ok {
+			items, ok := cur.([]any)
+			if !ok || idx >= len(items) {
+				return false
+			}
+			if last {
+				items[idx] = value
+				return true
+			}
+			cur = items[idx]
+			continue
+		}
+
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return false
+		}
+		key, ok := findSettingKey(m, seg.name)
+		if !ok {
+			key = strings.ToLower(seg.name)
+		}
+		if last {
+			m[key] = value
+			return true
+		}
+		next, ok := m[key]
+		if !ok || next == nil {
+			if _, isIndex := segs[i+1].index(); isIndex {
+				return false
+			}
+			next = make(map[string]any)
+			m[key] = next
+		}
+		cur = next
+	}
+	return false
+}
+
+// deleteSetting 按字段路径删除配置映射中的值
+// 删除后变为空的父级映射会一并移除
+func deleteSetting(settings map[string]any, path string) bool {
+	parent, name := splitParentPath(path)
+	var container any = settings
+	if parent != "" {
+		v, ok := lookupSetting(settings, parent)
+		if !ok {
+			return false
+		}
+		container = v
+	}
+	m, ok := container.(map[string]any)
+	if !ok {
+		return false
+	}
+	key, ok := findSettingKey(m, name)
+	if !ok {
+		return false
+	}
+	delete(m, key)
+	if len(m) == 0 && parent != "" {
+		deleteSetting(settings, parent)
+	}
+	return true
+}
+
+// findSettingKey 不区分大小写地查找映射中的键
+func findSettingKey(m map[string]any, name string) (string, bool) {
+	if _, ok := m[name]; ok {
+		return name, true
+	}
+	for k := range m {
+		if strings.EqualFold(k, name) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// splitParentPath 将 a.b.c 拆分为 a.b 与 c
+func splitParentPath(path string) (string, string) {
+	i := strings.LastIndex(path, ".")
+	if i < 0 {
+		return "", path
+	}
+	return path[:i], path[i+1:]
+}
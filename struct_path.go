@@ -0,0 +1,147 @@
+package configx
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// fieldPathType 按字段路径解析配置结构体中字段的类型
+// 路径格式与 Viper 一致（点号分隔，不区分大小写，按 mapstructure 标签匹配），切片元素使用 [index]，
+// 以字符串为键的映射可以用任意键名继续访问
+func fieldPathType(t reflect.Type, path string) (reflect.Type, error) {
+	segs := splitKeyPath(path)
+	if len(segs) == 0 {
+		return nil, fmt.Errorf("%w: %q", ErrFieldNotFound, path)
+	}
+	for i, seg := range segs {
+		t = indirectType(t)
+		if _, ok := seg.index(); ok {
+			if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+				return nil, fmt.Errorf("%w: %s 不是切片", ErrFieldNotFound, segmentPath(segs[:i+1]))
+			}
+			t = t.Elem()
+			continue
+		}
+		switch t.Kind() {
+		case reflect.Struct:
+			index, ok := findStructField(t, seg.name)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrFieldNotFound, segmentPath(segs[:i+1]))
+			}
+			t = t.FieldByIndex(index).Type
+		case reflect.Map:
+			if t.Key().Kind() != reflect.String {
+				return nil, fmt.Errorf("%w: %s 的键不是字符串", ErrFieldNotFound, segmentPath(segs[:i]))
+			}
+			t = t.Elem()
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrFieldNotFound, segmentPath(segs[:i+1]))
+		}
+	}
+	return t, nil
+}
+
+// lookupField 按字段路径读取配置结构体中的值
+// 返回值：
+//
+//	reflect.Value: 字段值
+//	bool: 路径上存在 nil 指针、不存在的映射键或越界的下标时返回 false
+func lookupField(v reflect.Value, path string) (reflect.Value, bool) {
+	for _, seg := range splitKeyPath(path) {
+		for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		if idx, ok := seg.index(); ok {
+			if (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) || idx >= v.Len() {
+				return reflect.Value{}, false
+			}
+			v = v.Index(idx)
+			continue
+		}
+		switch v.Kind() {
+		case reflect.Struct:
+			index, ok := findStructField(v.Type(), seg.name)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			v = v.FieldByIndex(index)
+		case reflect.Map:
+			key, ok := findMapKey(v, seg.name)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			v = v.MapIndex(key)
+		default:
+			return reflect.Value{}, false
+		}
+	}
+	return v, true
+}
+
+// findStructField 按 mapstructure 名称（不区分大小写）查找结构体字段
+// squash 嵌入的结构体字段视为外层结构体的字段
+func findStructField(t reflect.Type, name string) ([]int, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tagName, opts, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+		if tagName == "-" || strings.Contains(opts, "remain") {
+			continue
+		}
+		if strings.Contains(opts, "squash") || (f.Anonymous && tagName == "") {
+			if f.Type.Kind() == reflect.Struct {
+				if index, ok := findStructField(f.Type, name); ok {
+					return append([]int{i}, index...), true
+				}
+			}
+			continue
+		}
+		if tagName == "" {
+			tagName = f.Name
+		}
+		if strings.EqualFold(tagName, name) {
+			return []int{i}, true
+		}
+	}
+	return nil, false
+}
+
+// findMapKey 不区分大小写地查找以字符串为键的映射中的键
+func findMapKey(m reflect.Value, name string) (reflect.Value, bool) {
+	if m.Type().Key().Kind() != reflect.String {
+		return reflect.Value{}, false
+	}
+	key := reflect.ValueOf(name).Convert(m.Type().Key())
+	if m.MapIndex(key).IsValid() {
+		return key, true
+	}
+	iter := m.MapRange()
+	for iter.Next() {
+		if strings.EqualFold(iter.Key().String(), name) {
+			return iter.Key(), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// segmentPath 将路径片段还原为字段路径
+func segmentPath(segs []keySegment) string {
+	var b strings.Builder
+	for _, seg := range segs {
+		if idx, ok := seg.index(); ok {
+			fmt.Fprintf(&b, "[%d]", idx)
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(seg.name)
+	}
+	return b.String()
+}
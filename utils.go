@@ -2,6 +2,7 @@ package configx
 
 import (
 	"path/filepath"
+	"reflect"
 )
 
 // filepathABs 返回配置文件路径
@@ -9,3 +10,19 @@ func filepathAbs(p string) string {
 	path, _ := filepath.Abs(p)
 	return path
 }
+
+// indirectType 返回指针指向的最终类型
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// joinKeyPath 拼接配置字段路径
+func joinKeyPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
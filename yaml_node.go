@@ -0,0 +1,222 @@
+package configx
+
+import (
+	"bytes"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// findYAMLNode 按字段路径在 YAML 文档中查找对应的键节点
+// 路径格式与 Viper 一致（点号分隔，不区分大小写），切片元素使用 [index]
+// 参数：
+//
+//	root: YAML 文档节点，为 nil 时直接返回 nil
+//	path: 字段路径，如 servers[2].host
+//
+// 返回值：
+//
+//	*yaml.Node: 找到时返回键节点（用于定位行列号），否则返回 nil
+func findYAMLNode(root *yaml.Node, path string) *yaml.Node {
+	if root == nil {
+		return nil
+	}
+	node := root
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+		node = node.Content[0]
+	}
+
+	var keyNode *yaml.Node
+	for _, seg := range splitKeyPath(path) {
+		if idx, ok := seg.index(); ok {
+			if node.Kind != yaml.SequenceNode || idx >= len(node.Content) {
+				return nil
+			}
+			node = node.Content[idx]
+			keyNode = node
+			continue
+		}
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		found := false
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if strings.EqualFold(node.Content[i].Value, seg.name) {
+				keyNode, node = node.Content[i], node.Content[i+1]
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil
+		}
+	}
+	return keyNode
+}
+
+// keySegment 字段路径中的一段（字段名或切片下标）
+type keySegment struct {
+	name string
+	idx  int
+}
+
+// index 返回切片下标（非下标段返回 false）
+func (s keySegment) index() (int, bool) {
+	return s.idx, s.idx >= 0
+}
+
+// splitKeyPath 将 a.b[1].c 形式的路径拆分为字段段
+func splitKeyPath(path string) []keySegment {
+	var segs []keySegment
+	for _, part := range strings.Split(path, ".") {
+		name, rest, _ := strings.Cut(part, "[")
+		if name != "" {
+			segs = append(segs, keySegment{name: name, idx: -1})
+		}
+		for rest != "" {
+			num, tail, ok := strings.Cut(rest, "]")
+			if !ok {
+				break
+			}
+			if i, err := strconv.Atoi(num); err == nil {
+				segs = append(segs, keySegment{idx: i})
+			}
+			rest = strings.TrimPrefix(tail, "[")
+		}
+	}
+	return segs
+}
+
+// yamlRoot 返回文档节点下的根映射节点
+func yamlRoot(doc *yaml.Node) *yaml.Node {
+	if doc == nil {
+		return nil
+	}
+	if doc.Kind == yaml.DocumentNode {
+		if len(doc.Content) == 0 {
+			return nil
+		}
+		return doc.Content[0]
+	}
+	return doc
+}
+
+// removeYAMLKey 从 YAML 文档中移除指定路径的键值对
+// 移除后变为空的父级映射会一并删除
+// 返回值：
+//
+//	key, value: 被移除的键节点与值节点，路径不存在时均为 nil
+func removeYAMLKey(doc *yaml.Node, path string) (*yaml.Node, *yaml.Node) {
+	parentPath, name := splitParentPath(path)
+	parent := yamlRoot(doc)
+	if parentPath != "" {
+		parent = yamlValueOf(doc, parentPath)
+	}
+	if parent == nil || parent.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(parent.Content); i += 2 {
+		if strings.EqualFold(parent.Content[i].Value, name) {
+			key, value := parent.Content[i], parent.Content[i+1]
+			parent.Content = append(parent.Content[:i], parent.Content[i+2:]...)
+			// 移除后变为空的父级映射一并删除
+			if len(parent.Content) == 0 && parentPath != "" {
+				removeYAMLKey(doc, parentPath)
+			}
+			return key, value
+		}
+	}
+	return nil, nil
+}
+
+// insertYAMLKey 在 YAML 文档的指定路径插入键值对，缺失的中间映射会自动创建
+// 返回值：
+//
+//	bool: 路径已存在或穿过非映射节点时返回 false
+func insertYAMLKey(doc *yaml.Node, path string, key, value *yaml.Node) bool {
+	node := yamlRoot(doc)
+	segs := strings.Split(path, ".")
+	for i, seg := range segs {
+		if node == nil || node.Kind != yaml.MappingNode {
+			return false
+		}
+		var next *yaml.Node
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			if strings.EqualFold(node.Content[j].Value, seg) {
+				next = node.Content[j+1]
+				break
+			}
+		}
+		if i == len(segs)-1 {
+			if next != nil {
+				return false
+			}
+			key.Value = seg
+			node.Content = append(node.Content, key, value)
+			return true
+		}
+		if next == nil {
+			next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: seg}, next)
+		}
+		node = next
+	}
+	return false
+}
+
+// yamlValueOf 返回指定路径对应的值节点
+func yamlValueOf(doc *yaml.Node, path string) *yaml.Node {
+	node := yamlRoot(doc)
+	for _, seg := range splitKeyPath(path) {
+		if node == nil {
+			return nil
+		}
+		if idx, ok := seg.index(); ok {
+			if node.Kind != yaml.SequenceNode || idx >= len(node.Content) {
+				return nil
+			}
+			node = node.Content[idx]
+			continue
+		}
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if strings.EqualFold(node.Content[i].Value, seg.name) {
+				next = node.Content[i+1]
+				break
+			}
+		}
+		node = next
+	}
+	return node
+}
+
+// encodeYAML 将 YAML 文档编码为字节（两空格缩进，保留注释）
+func encodeYAML(doc *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// isYAMLFile 判断配置文件是否为 YAML 格式
+func isYAMLFile(file string) bool {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		return true
+	}
+	return false
+}